@@ -0,0 +1,210 @@
+package goeditorjs
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// AdmonitionStyle is the markdown syntax used for admonitions like warnings and alerts
+type AdmonitionStyle string
+
+const (
+	// AdmonitionDefault uses the admonition style of the MarkdownEngine's dialect, AdmonitionGFM by default
+	AdmonitionDefault AdmonitionStyle = ""
+	// AdmonitionGFM uses GitHub alerts: "> [!WARNING]"
+	AdmonitionGFM AdmonitionStyle = "gfm"
+	// AdmonitionObsidian uses Obsidian callouts, which support titles: "> [!warning] Title"
+	AdmonitionObsidian AdmonitionStyle = "obsidian"
+	// AdmonitionMkDocs uses the admonition extension of Python-Markdown and MkDocs: `!!! warning "Title"`
+	AdmonitionMkDocs AdmonitionStyle = "mkdocs"
+	// AdmonitionDirective uses container directives as understood by Docusaurus, VitePress and markdown-it-container:
+	// ":::warning Title"
+	AdmonitionDirective AdmonitionStyle = "directive"
+	// AdmonitionBlockquote uses a plain blockquote with a bold title, which renders in every dialect
+	AdmonitionBlockquote AdmonitionStyle = "blockquote"
+)
+
+// Admonition kinds, named after the GitHub alert types
+const (
+	admonitionNote      = "note"
+	admonitionTip       = "tip"
+	admonitionImportant = "important"
+	admonitionWarning   = "warning"
+	admonitionCaution   = "caution"
+)
+
+// admonition renders an admonition of kind with an optional title in the given style
+func admonition(style AdmonitionStyle, kind, title, message string) string {
+	body := strings.Split(strings.TrimSpace(message), "\n")
+	quote := func(lines []string) string {
+		for i, line := range lines {
+			lines[i] = strings.TrimRight("> "+line, " ")
+		}
+		return strings.Join(lines, "\n")
+	}
+
+	switch style {
+	case AdmonitionObsidian:
+		return quote(append([]string{strings.TrimSpace(fmt.Sprintf("[!%s] %s", kind, title))}, body...))
+	case AdmonitionMkDocs:
+		head := "!!! " + kind
+		if title != "" {
+			head += fmt.Sprintf(" %q", title)
+		}
+		for i, line := range body {
+			body[i] = strings.TrimRight("    "+line, " ")
+		}
+		return head + "\n\n" + strings.Join(body, "\n")
+	case AdmonitionDirective:
+		return strings.TrimSpace(":::"+kind+" "+title) + "\n" + strings.Join(body, "\n") + "\n:::"
+	case AdmonitionBlockquote:
+		if title == "" {
+			title = strings.ToUpper(kind[:1]) + kind[1:]
+		}
+		return quote(append([]string{"**" + title + "**", ""}, body...))
+	}
+
+	lines := []string{"[!" + strings.ToUpper(kind) + "]"}
+	if title != "" {
+		lines = append(lines, "**"+title+"**")
+	}
+	return quote(append(lines, body...))
+}
+
+// WarningHandler is the default WarningHandler for EditorJS HTML and markdown generation
+type WarningHandler struct {
+	// Style is the markdown syntax of the warning. If not set, the style of the engine's dialect is used.
+	Style AdmonitionStyle
+	// Classes override the engine's ClassMap for the elements this handler emits.
+	// The keys are "warning", "warning__title" and "warning__message".
+	Classes ClassMap
+}
+
+func (*WarningHandler) parse(editorJSBlock EditorJSBlock) (*Warning, error) {
+	warning := &Warning{}
+	return warning, json.Unmarshal(editorJSBlock.Data, warning)
+}
+
+// Type "warning"
+func (*WarningHandler) Type() string {
+	return "warning"
+}
+
+// GenerateHTML generates html for WarningBlocks
+func (h *WarningHandler) GenerateHTML(editorJSBlock EditorJSBlock) (string, error) {
+	return h.GenerateHTMLWithEngine(editorJSBlock, nil)
+}
+
+// GenerateHTMLWithEngine generates html for WarningBlocks using the engine's ClassMap
+func (h *WarningHandler) GenerateHTMLWithEngine(editorJSBlock EditorJSBlock, engine *HTMLEngine) (string, error) {
+	warning, err := h.parse(editorJSBlock)
+	if err != nil {
+		return "", err
+	}
+
+	title := ""
+	if warning.Title != "" {
+		title = fmt.Sprintf("<p%s>%s</p>", classAttr(h.Classes, engine, "warning__title", "warning__title"), warning.Title)
+	}
+	message := fmt.Sprintf("<p%s>%s</p>", classAttr(h.Classes, engine, "warning__message", "warning__message"), warning.Message)
+	return fmt.Sprintf(`<aside%s role="note">%s%s</aside>`, classAttr(h.Classes, engine, "warning", "warning"), title, message), nil
+}
+
+// GenerateMarkdown generates markdown for WarningBlocks
+func (h *WarningHandler) GenerateMarkdown(editorJSBlock EditorJSBlock) (string, error) {
+	return h.GenerateMarkdownWithEngine(editorJSBlock, nil)
+}
+
+// GenerateMarkdownWithEngine generates markdown for WarningBlocks in the handler's style, or the style of the engine's
+// dialect if the handler has none
+func (h *WarningHandler) GenerateMarkdownWithEngine(editorJSBlock EditorJSBlock, engine *MarkdownEngine) (string, error) {
+	warning, err := h.parse(editorJSBlock)
+	if err != nil {
+		return "", err
+	}
+
+	style := engine.dialect().admonitions(h.Style)
+	return admonition(style, admonitionWarning, markdownInlineLine(warning.Title, " ", engine.dialect()), markdownInline(warning.Message, engine.dialect())), nil
+}
+
+// alertTypes are the types of the alert tool, which are rendered as class names
+var alertTypes = map[string]bool{"primary": true, "secondary": true, "info": true, "success": true, "warning": true,
+	"danger": true, "light": true, "dark": true}
+
+// alertAligns are the alignments of the alert tool
+var alertAligns = map[string]bool{"left": true, "center": true, "right": true, "justify": true}
+
+// AlertHandler is the default AlertHandler for the EditorJS alert tool
+type AlertHandler struct {
+	// Style is the markdown syntax of the alert. If not set, the style of the engine's dialect is used.
+	Style AdmonitionStyle
+	// Classes override the engine's ClassMap for the elements this handler emits. The key is "alert".
+	Classes ClassMap
+}
+
+func (*AlertHandler) parse(editorJSBlock EditorJSBlock) (*Alert, error) {
+	alert := &Alert{}
+	return alert, json.Unmarshal(editorJSBlock.Data, alert)
+}
+
+// Type "alert"
+func (*AlertHandler) Type() string {
+	return "alert"
+}
+
+// GenerateHTML generates html for AlertBlocks
+func (h *AlertHandler) GenerateHTML(editorJSBlock EditorJSBlock) (string, error) {
+	return h.GenerateHTMLWithEngine(editorJSBlock, nil)
+}
+
+// GenerateHTMLWithEngine generates html for AlertBlocks using the engine's ClassMap
+func (h *AlertHandler) GenerateHTMLWithEngine(editorJSBlock EditorJSBlock, engine *HTMLEngine) (string, error) {
+	alert, err := h.parse(editorJSBlock)
+	if err != nil {
+		return "", err
+	}
+
+	kind := alert.Type
+	if !alertTypes[kind] {
+		kind = "info"
+	}
+	style := ""
+	if alertAligns[alert.Align] && alert.Align != "left" {
+		style = fmt.Sprintf(` style="text-align:%s"`, alert.Align)
+	}
+	class := classAttr(h.Classes, engine, "alert", "alert", "alert--"+kind)
+	return fmt.Sprintf(`<div%s%s role="alert">%s</div>`, class, style, alert.Message), nil
+}
+
+// GenerateMarkdown generates markdown for AlertBlocks
+func (h *AlertHandler) GenerateMarkdown(editorJSBlock EditorJSBlock) (string, error) {
+	return h.GenerateMarkdownWithEngine(editorJSBlock, nil)
+}
+
+// GenerateMarkdownWithEngine generates markdown for AlertBlocks in the handler's style, or the style of the engine's
+// dialect if the handler has none
+func (h *AlertHandler) GenerateMarkdownWithEngine(editorJSBlock EditorJSBlock, engine *MarkdownEngine) (string, error) {
+	alert, err := h.parse(editorJSBlock)
+	if err != nil {
+		return "", err
+	}
+
+	style := engine.dialect().admonitions(h.Style)
+	return admonition(style, alertAdmonitionKind(alert.Type), "", markdownInline(alert.Message, engine.dialect())), nil
+}
+
+// alertAdmonitionKind maps the types of the alert tool to admonition kinds
+func alertAdmonitionKind(alertType string) string {
+	switch alertType {
+	case "success":
+		return admonitionTip
+	case "primary":
+		return admonitionImportant
+	case "warning":
+		return admonitionWarning
+	case "danger":
+		return admonitionCaution
+	}
+	return admonitionNote
+}
@@ -0,0 +1,110 @@
+package goeditorjs_test
+
+import (
+	"testing"
+
+	"github.com/davidscottmills/goeditorjs"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_WarningHandler_Type(t *testing.T) {
+	h := &goeditorjs.WarningHandler{}
+	require.Equal(t, "warning", h.Type())
+}
+
+func Test_WarningHandler_Returns_Parse_Err(t *testing.T) {
+	h := &goeditorjs.WarningHandler{}
+	_, err := h.GenerateHTML(goeditorjs.EditorJSBlock{Type: "warning", Data: []byte{}})
+	require.Error(t, err)
+	_, err = h.GenerateMarkdown(goeditorjs.EditorJSBlock{Type: "warning", Data: []byte{}})
+	require.Error(t, err)
+}
+
+func Test_WarningHandler_GenerateHTML(t *testing.T) {
+	h := &goeditorjs.WarningHandler{}
+	testData := []struct {
+		data           string
+		expectedResult string
+	}{
+		{data: `{"title": "Note:", "message": "Be <b>careful</b>"}`,
+			expectedResult: `<aside class="warning" role="note"><p class="warning__title">Note:</p><p class="warning__message">Be <b>careful</b></p></aside>`},
+		{data: `{"title": "", "message": "Be careful"}`,
+			expectedResult: `<aside class="warning" role="note"><p class="warning__message">Be careful</p></aside>`},
+	}
+
+	for _, td := range testData {
+		result, err := h.GenerateHTML(goeditorjs.EditorJSBlock{Type: "warning", Data: []byte(td.data)})
+		require.NoError(t, err)
+		require.Equal(t, td.expectedResult, result)
+	}
+}
+
+func Test_WarningHandler_GenerateMarkdown(t *testing.T) {
+	data := []byte(`{"title": "Note:", "message": "Be <code>careful</code><br>really"}`)
+	testData := []struct {
+		style          goeditorjs.AdmonitionStyle
+		expectedResult string
+	}{
+		{style: goeditorjs.AdmonitionDefault, expectedResult: "> [!WARNING]\n> **Note:**\n> Be `careful`\\\n> really"},
+		{style: goeditorjs.AdmonitionGFM, expectedResult: "> [!WARNING]\n> **Note:**\n> Be `careful`\\\n> really"},
+		{style: goeditorjs.AdmonitionObsidian, expectedResult: "> [!warning] Note:\n> Be `careful`\\\n> really"},
+		{style: goeditorjs.AdmonitionMkDocs, expectedResult: "!!! warning \"Note:\"\n\n    Be `careful`\\\n    really"},
+		{style: goeditorjs.AdmonitionDirective, expectedResult: ":::warning Note:\nBe `careful`\\\nreally\n:::"},
+		{style: goeditorjs.AdmonitionBlockquote, expectedResult: "> **Note:**\n>\n> Be `careful`\\\n> really"},
+	}
+
+	for _, td := range testData {
+		h := &goeditorjs.WarningHandler{Style: td.style}
+		result, err := h.GenerateMarkdown(goeditorjs.EditorJSBlock{Type: "warning", Data: data})
+		require.NoError(t, err)
+		require.Equal(t, td.expectedResult, result)
+	}
+}
+
+func Test_AlertHandler_Type(t *testing.T) {
+	h := &goeditorjs.AlertHandler{}
+	require.Equal(t, "alert", h.Type())
+}
+
+func Test_AlertHandler_Returns_Parse_Err(t *testing.T) {
+	h := &goeditorjs.AlertHandler{}
+	_, err := h.GenerateHTML(goeditorjs.EditorJSBlock{Type: "alert", Data: []byte{}})
+	require.Error(t, err)
+	_, err = h.GenerateMarkdown(goeditorjs.EditorJSBlock{Type: "alert", Data: []byte{}})
+	require.Error(t, err)
+}
+
+func Test_AlertHandler_GenerateHTML(t *testing.T) {
+	h := &goeditorjs.AlertHandler{}
+	result, err := h.GenerateHTML(goeditorjs.EditorJSBlock{Type: "alert", Data: []byte(`{"type": "danger", "align": "center", "message": "Stop"}`)})
+	require.NoError(t, err)
+	require.Equal(t, `<div class="alert alert--danger" style="text-align:center" role="alert">Stop</div>`, result)
+
+	// unknown types and alignments can't break out of their attributes
+	result, err = h.GenerateHTML(goeditorjs.EditorJSBlock{Type: "alert", Data: []byte(`{"type": "x\" onclick=\"alert(1)", "align": "center\" onmouseover=\"alert(1)", "message": "Stop"}`)})
+	require.NoError(t, err)
+	require.Equal(t, `<div class="alert alert--info" role="alert">Stop</div>`, result)
+}
+
+func Test_AlertHandler_GenerateMarkdown(t *testing.T) {
+	testData := []struct {
+		alertType string
+		style     goeditorjs.AdmonitionStyle
+		expected  string
+	}{
+		{alertType: "info", expected: "> [!NOTE]\n> Stop"},
+		{alertType: "success", expected: "> [!TIP]\n> Stop"},
+		{alertType: "primary", expected: "> [!IMPORTANT]\n> Stop"},
+		{alertType: "warning", expected: "> [!WARNING]\n> Stop"},
+		{alertType: "danger", expected: "> [!CAUTION]\n> Stop"},
+		{alertType: "danger", style: goeditorjs.AdmonitionBlockquote, expected: "> **Caution**\n>\n> Stop"},
+		{alertType: "danger", style: goeditorjs.AdmonitionMkDocs, expected: "!!! caution\n\n    Stop"},
+	}
+
+	for _, td := range testData {
+		h := &goeditorjs.AlertHandler{Style: td.style}
+		result, err := h.GenerateMarkdown(goeditorjs.EditorJSBlock{Type: "alert", Data: []byte(`{"type": "` + td.alertType + `", "message": "Stop"}`)})
+		require.NoError(t, err)
+		require.Equal(t, td.expected, result)
+	}
+}
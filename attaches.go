@@ -0,0 +1,131 @@
+package goeditorjs
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// AttachesHandler is the default AttachesHandler for EditorJS HTML and markdown generation
+type AttachesHandler struct {
+	// Options are made available to the GenerateHTML and GenerateMarkdown functions.
+	// If not provided, DefaultAttachesHandlerOptions will be used.
+	Options *AttachesHandlerOptions
+	// Classes override the engine's ClassMap for the elements this handler emits. The keys are "attaches",
+	// "attaches__icon", "attaches__title" and "attaches__size".
+	Classes ClassMap
+}
+
+// AttachesHandlerOptions are the options available to the AttachesHandler
+type AttachesHandlerOptions struct {
+	// IconClassPrefix is prefixed to the file extension to form the icon class, e.g. "attaches__icon--pdf"
+	IconClassPrefix string
+	// RewriteURL rewrites the file url, e.g. StaticDomainRewriter("https://cdn.example.com")
+	RewriteURL URLRewriter
+}
+
+// DefaultAttachesHandlerOptions are the default options available to the AttachesHandler
+var DefaultAttachesHandlerOptions = &AttachesHandlerOptions{IconClassPrefix: "attaches__icon--"}
+
+func (h *AttachesHandler) parse(editorJSBlock EditorJSBlock) (*Attaches, error) {
+	if h.Options == nil {
+		h.Options = DefaultAttachesHandlerOptions
+	}
+
+	attaches := &Attaches{}
+	if err := json.Unmarshal(editorJSBlock.Data, attaches); err != nil {
+		return nil, err
+	}
+	attaches.File.URL = h.Options.RewriteURL.rewrite(attaches.File.URL)
+	if attaches.Title == "" {
+		attaches.Title = attaches.File.Name
+	}
+	if attaches.Title == "" {
+		attaches.Title = urlFileName(attaches.File.URL)
+	}
+	if attaches.Title == "" {
+		attaches.Title = "Attachment"
+	}
+	if attaches.File.Extension == "" {
+		attaches.File.Extension = strings.TrimPrefix(path.Ext(attaches.File.Name), ".")
+	}
+	attaches.File.Extension = strings.ToLower(attaches.File.Extension)
+	return attaches, nil
+}
+
+// Type "attaches"
+func (*AttachesHandler) Type() string {
+	return "attaches"
+}
+
+// GenerateHTML generates html for AttachesBlocks
+func (h *AttachesHandler) GenerateHTML(editorJSBlock EditorJSBlock) (string, error) {
+	return h.GenerateHTMLWithEngine(editorJSBlock, nil)
+}
+
+// GenerateHTMLWithEngine generates html for AttachesBlocks using the engine's ClassMap
+func (h *AttachesHandler) GenerateHTMLWithEngine(editorJSBlock EditorJSBlock, engine *HTMLEngine) (string, error) {
+	attaches, err := h.parse(editorJSBlock)
+	if err != nil {
+		return "", err
+	}
+
+	class := func(name string, extra ...string) string {
+		return classAttr(h.Classes, engine, name, append([]string{name}, extra...)...)
+	}
+	icon := ""
+	if attaches.File.Extension != "" {
+		icon = h.Options.IconClassPrefix + attaches.File.Extension
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf(`<a%s%s download>`, class("attaches"), hrefAttr(attaches.File.URL)))
+	sb.WriteString(fmt.Sprintf(`<span%s>%s</span>`, class("attaches__icon", icon), html.EscapeString(strings.ToUpper(attaches.File.Extension))))
+	sb.WriteString(fmt.Sprintf(`<span%s>%s</span>`, class("attaches__title"), html.EscapeString(attaches.Title)))
+	if attaches.File.Size > 0 {
+		sb.WriteString(fmt.Sprintf(`<span%s>%s</span>`, class("attaches__size"), humanSize(attaches.File.Size)))
+	}
+	sb.WriteString("</a>")
+
+	return sb.String(), nil
+}
+
+// GenerateMarkdown generates markdown for AttachesBlocks
+func (h *AttachesHandler) GenerateMarkdown(editorJSBlock EditorJSBlock) (string, error) {
+	attaches, err := h.parse(editorJSBlock)
+	if err != nil {
+		return "", err
+	}
+
+	annotations := []string{}
+	if attaches.File.Extension != "" {
+		annotations = append(annotations, strings.ToUpper(attaches.File.Extension))
+	}
+	if attaches.File.Size > 0 {
+		annotations = append(annotations, humanSize(attaches.File.Size))
+	}
+
+	md := markdownLink(escapeMarkdown(attaches.Title), attaches.File.URL)
+	if len(annotations) > 0 {
+		md += fmt.Sprintf(" (%s)", strings.Join(annotations, ", "))
+	}
+	return md, nil
+}
+
+// humanSize formats a size in bytes using binary units, e.g. "1.5 MB"
+func humanSize(bytes float64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", int64(bytes))
+	}
+
+	units := []string{"KB", "MB", "GB", "TB", "PB"}
+	i := 0
+	for bytes /= unit; bytes >= unit && i < len(units)-1; i++ {
+		bytes /= unit
+	}
+	return strings.TrimSuffix(strconv.FormatFloat(bytes, 'f', 1, 64), ".0") + " " + units[i]
+}
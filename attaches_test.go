@@ -0,0 +1,79 @@
+package goeditorjs_test
+
+import (
+	"testing"
+
+	"github.com/davidscottmills/goeditorjs"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AttachesHandler_Type(t *testing.T) {
+	h := &goeditorjs.AttachesHandler{}
+	require.Equal(t, "attaches", h.Type())
+}
+
+func Test_AttachesHandler_Returns_Parse_Err(t *testing.T) {
+	h := &goeditorjs.AttachesHandler{}
+	_, err := h.GenerateHTML(goeditorjs.EditorJSBlock{Type: "attaches", Data: []byte{}})
+	require.Error(t, err)
+	_, err = h.GenerateMarkdown(goeditorjs.EditorJSBlock{Type: "attaches", Data: []byte{}})
+	require.Error(t, err)
+}
+
+func Test_AttachesHandler_GenerateHTML(t *testing.T) {
+	h := &goeditorjs.AttachesHandler{}
+	testData := []struct {
+		data           string
+		expectedResult string
+	}{
+		{data: `{"file": {"url": "https://example.com/q3.pdf", "name": "q3.pdf", "size": 1572864, "extension": "PDF"}, "title": "Q3 <report>"}`,
+			expectedResult: `<a class="attaches" href="https://example.com/q3.pdf" download>` +
+				`<span class="attaches__icon attaches__icon--pdf">PDF</span>` +
+				`<span class="attaches__title">Q3 &lt;report&gt;</span>` +
+				`<span class="attaches__size">1.5 MB</span></a>`},
+		{data: `{"file": {"url": "/files/data.xlsx", "name": "data.xlsx", "size": 512}, "title": ""}`,
+			expectedResult: `<a class="attaches" href="/files/data.xlsx" download>` +
+				`<span class="attaches__icon attaches__icon--xlsx">XLSX</span>` +
+				`<span class="attaches__title">data.xlsx</span>` +
+				`<span class="attaches__size">512 B</span></a>`},
+		{data: `{"file": {"url": "/files/blob"}}`,
+			expectedResult: `<a class="attaches" href="/files/blob" download>` +
+				`<span class="attaches__icon"></span><span class="attaches__title">blob</span></a>`},
+		{data: `{"file": {"url": ""}}`,
+			expectedResult: `<a class="attaches" href="" download>` +
+				`<span class="attaches__icon"></span><span class="attaches__title">Attachment</span></a>`},
+		{data: `{"file": {"url": "https://example.com"}}`,
+			expectedResult: `<a class="attaches" href="https://example.com" download>` +
+				`<span class="attaches__icon"></span><span class="attaches__title">Attachment</span></a>`},
+		{data: `{"file": {"url": "data:text/html;base64,PHNjcmlwdD4="}, "title": "x"}`,
+			expectedResult: `<a class="attaches" download>` +
+				`<span class="attaches__icon"></span><span class="attaches__title">x</span></a>`},
+	}
+
+	for _, td := range testData {
+		result, err := h.GenerateHTML(goeditorjs.EditorJSBlock{Type: "attaches", Data: []byte(td.data)})
+		require.NoError(t, err)
+		require.Equal(t, td.expectedResult, result)
+	}
+}
+
+func Test_AttachesHandler_GenerateMarkdown(t *testing.T) {
+	h := &goeditorjs.AttachesHandler{Options: &goeditorjs.AttachesHandlerOptions{RewriteURL: goeditorjs.StaticDomainRewriter("https://cdn.example.com")}}
+	testData := []struct {
+		data           string
+		expectedResult string
+	}{
+		{data: `{"file": {"url": "/q3.pdf", "name": "q3.pdf", "size": 2048, "extension": "pdf"}, "title": "Q3 report"}`,
+			expectedResult: `[Q3 report](https://cdn.example.com/q3.pdf) (PDF, 2 KB)`},
+		{data: `{"file": {"url": "/blob"}}`, expectedResult: `[blob](https://cdn.example.com/blob)`},
+		{data: `{"file": {"url": "/files/?download=1"}}`, expectedResult: `[Attachment](https://cdn.example.com/files/?download=1)`},
+		{data: `{"file": {"url": "/a%20b.txt#top"}}`, expectedResult: `[a b.txt](https://cdn.example.com/a%20b.txt#top)`},
+		{data: `{"file": {"url": "javascript:alert(1)"}, "title": "x"}`, expectedResult: `x`},
+	}
+
+	for _, td := range testData {
+		result, err := h.GenerateMarkdown(goeditorjs.EditorJSBlock{Type: "attaches", Data: []byte(td.data)})
+		require.NoError(t, err)
+		require.Equal(t, td.expectedResult, result)
+	}
+}
@@ -0,0 +1,75 @@
+package goeditorjs
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ChecklistHandler is the default ChecklistHandler for EditorJS HTML and markdown generation
+type ChecklistHandler struct {
+	// Classes override the engine's ClassMap for the elements this handler emits.
+	// The keys are "checklist" and "checklist__item".
+	Classes ClassMap
+}
+
+func (*ChecklistHandler) parse(editorJSBlock EditorJSBlock) (*Checklist, error) {
+	checklist := &Checklist{}
+	return checklist, json.Unmarshal(editorJSBlock.Data, checklist)
+}
+
+// Type "checklist"
+func (*ChecklistHandler) Type() string {
+	return "checklist"
+}
+
+// GenerateHTML generates html for ChecklistBlocks
+func (h *ChecklistHandler) GenerateHTML(editorJSBlock EditorJSBlock) (string, error) {
+	return h.GenerateHTMLWithEngine(editorJSBlock, nil)
+}
+
+// GenerateHTMLWithEngine generates html for ChecklistBlocks using the engine's ClassMap
+func (h *ChecklistHandler) GenerateHTMLWithEngine(editorJSBlock EditorJSBlock, engine *HTMLEngine) (string, error) {
+	checklist, err := h.parse(editorJSBlock)
+	if err != nil {
+		return "", err
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("<ul%s>", classAttr(h.Classes, engine, "checklist", "checklist")))
+	for _, item := range checklist.Items {
+		checked := ""
+		if item.Checked {
+			checked = " checked"
+		}
+		sb.WriteString(fmt.Sprintf(`<li%s><input type="checkbox" disabled%s> %s</li>`,
+			classAttr(h.Classes, engine, "checklist__item", "checklist__item"), checked, item.Text))
+	}
+	sb.WriteString("</ul>")
+	return sb.String(), nil
+}
+
+// GenerateMarkdown generates markdown for ChecklistBlocks
+func (h *ChecklistHandler) GenerateMarkdown(editorJSBlock EditorJSBlock) (string, error) {
+	return h.GenerateMarkdownWithEngine(editorJSBlock, nil)
+}
+
+// GenerateMarkdownWithEngine generates task lists for ChecklistBlocks, or lists with ballot boxes for dialects
+// without task lists
+func (h *ChecklistHandler) GenerateMarkdownWithEngine(editorJSBlock EditorJSBlock, engine *MarkdownEngine) (string, error) {
+	checklist, err := h.parse(editorJSBlock)
+	if err != nil {
+		return "", err
+	}
+
+	box := map[bool]string{false: "[ ]", true: "[x]"}
+	if !engine.dialect().TaskLists {
+		box = map[bool]string{false: "☐", true: "☑"}
+	}
+	lines := make([]string, len(checklist.Items))
+	for i, item := range checklist.Items {
+		// continuation lines are indented to stay part of the item
+		lines[i] = markdownListItem("- "+box[item.Checked]+" ", markdownInline(item.Text, engine.dialect()))
+	}
+	return strings.Join(lines, "\n"), nil
+}
@@ -0,0 +1,36 @@
+package goeditorjs_test
+
+import (
+	"testing"
+
+	"github.com/davidscottmills/goeditorjs"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ChecklistHandler_Type(t *testing.T) {
+	h := &goeditorjs.ChecklistHandler{}
+	require.Equal(t, "checklist", h.Type())
+}
+
+func Test_ChecklistHandler_Returns_Parse_Err(t *testing.T) {
+	h := &goeditorjs.ChecklistHandler{}
+	_, err := h.GenerateHTML(goeditorjs.EditorJSBlock{Type: "checklist", Data: []byte{}})
+	require.Error(t, err)
+	_, err = h.GenerateMarkdown(goeditorjs.EditorJSBlock{Type: "checklist", Data: []byte{}})
+	require.Error(t, err)
+}
+
+func Test_ChecklistHandler_GenerateHTML(t *testing.T) {
+	h := &goeditorjs.ChecklistHandler{}
+	result, err := h.GenerateHTML(goeditorjs.EditorJSBlock{Type: "checklist", Data: []byte(`{"items": [{"text": "done", "checked": true}, {"text": "<b>todo</b>"}]}`)})
+	require.NoError(t, err)
+	require.Equal(t, `<ul class="checklist"><li class="checklist__item"><input type="checkbox" disabled checked> done</li>`+
+		`<li class="checklist__item"><input type="checkbox" disabled> <b>todo</b></li></ul>`, result)
+}
+
+func Test_ChecklistHandler_GenerateMarkdown(t *testing.T) {
+	h := &goeditorjs.ChecklistHandler{}
+	result, err := h.GenerateMarkdown(goeditorjs.EditorJSBlock{Type: "checklist", Data: []byte(`{"items": [{"text": "done", "checked": true}, {"text": "<code>todo</code>"}]}`)})
+	require.NoError(t, err)
+	require.Equal(t, "- [x] done\n- [ ] `todo`", result)
+}
@@ -0,0 +1,108 @@
+package goeditorjs
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// ClassMap maps the elements emitted by the built-in HTML handlers to their class attribute.
+// Keys are element names ("h1" to "h6", "p", "ul", "ol", "li", "pre", "code", "table", "tr", "th", "td",
+// "blockquote", "cite", "img", "figure", "figcaption", "hr", "details", "summary", "audio", "video") or, for elements
+// that carry a fixed class already, that class ("warning", "warning__title", "warning__message", "alert", "math",
+// "math-inline", "mermaid", "plantuml", "graphviz", "columns", "columns__column", "footnotes", "footnote-ref",
+// "footnote-backref", "checklist", "checklist__item", "diff--added", "diff--removed", "diff--modified",
+// "diff--moved").
+type ClassMap map[string]string
+
+// ClassMapNone emits no classes
+var ClassMapNone = ClassMap{}
+
+// ClassMapEditorJS emits the classes used by the editor.js tools themselves, so their stylesheets can be reused
+var ClassMapEditorJS = ClassMap{
+	"h1":         "ce-header",
+	"h2":         "ce-header",
+	"h3":         "ce-header",
+	"h4":         "ce-header",
+	"h5":         "ce-header",
+	"h6":         "ce-header",
+	"p":          "ce-paragraph cdx-block",
+	"ul":         "cdx-list cdx-list--unordered",
+	"ol":         "cdx-list cdx-list--ordered",
+	"li":         "cdx-list__item",
+	"pre":        "ce-code",
+	"code":       "ce-code__textarea",
+	"table":      "tc-table",
+	"tr":         "tc-row",
+	"th":         "tc-cell tc-cell--heading",
+	"td":         "tc-cell",
+	"blockquote": "cdx-quote",
+	"cite":       "cdx-quote__caption",
+	"img":        "image-tool__image-picture",
+	"figure":     "image-tool",
+	"figcaption": "image-tool__caption",
+	"hr":         "ce-delimiter",
+	"warning":    "cdx-warning",
+	"alert":      "cdx-alert",
+}
+
+// ClassMapTailwindTypography emits Tailwind utility classes mirroring the Tailwind Typography scale, for content that
+// isn't rendered inside a "prose" container or needs to override it
+var ClassMapTailwindTypography = ClassMap{
+	"h1":         "text-4xl font-extrabold tracking-tight mt-0 mb-8",
+	"h2":         "text-2xl font-bold mt-12 mb-6",
+	"h3":         "text-xl font-semibold mt-8 mb-3",
+	"h4":         "text-lg font-semibold mt-6 mb-2",
+	"h5":         "text-base font-semibold mt-6 mb-2",
+	"h6":         "text-base font-medium mt-6 mb-2",
+	"p":          "my-5 leading-7",
+	"ul":         "list-disc pl-6 my-5",
+	"ol":         "list-decimal pl-6 my-5",
+	"li":         "my-2",
+	"pre":        "overflow-x-auto rounded-md bg-gray-800 text-gray-200 px-4 py-3 my-6 text-sm",
+	"code":       "font-mono",
+	"table":      "w-full table-auto text-left text-sm my-8",
+	"tr":         "border-b border-gray-200",
+	"th":         "font-semibold px-2 py-2 align-bottom",
+	"td":         "px-2 py-2 align-top",
+	"blockquote": "border-l-4 border-gray-300 pl-4 italic my-8",
+	"cite":       "block not-italic text-sm text-gray-500 mt-2",
+	"img":        "rounded-md my-8",
+	"figure":     "my-8",
+	"figcaption": "mt-3 text-sm text-gray-500 text-center",
+	"hr":         "my-12 border-gray-200",
+	"warning":    "my-6 rounded-md border-l-4 border-yellow-400 bg-yellow-50 px-4 py-3",
+	"alert":      "my-6 rounded-md px-4 py-3",
+}
+
+// WithClassMap sets the ClassMap of the HTMLEngine
+func WithClassMap(classMap ClassMap) HTMLEngineOptions {
+	return func(h *HTMLEngine) {
+		h.ClassMap = classMap
+	}
+}
+
+// classAttr returns the class attribute for element, taking the class from the handler's classes first and the
+// engine's ClassMap second. extra classes are put in front. It returns an empty string if there are no classes.
+func classAttr(handlerClasses ClassMap, engine *HTMLEngine, element string, extra ...string) string {
+	classes := []string{}
+	for _, c := range extra {
+		if c != "" {
+			classes = append(classes, c)
+		}
+	}
+
+	if c, ok := handlerClasses[element]; ok {
+		classes = append(classes, c)
+	} else if engine != nil {
+		if c, ok := engine.ClassMap[element]; ok {
+			classes = append(classes, c)
+		}
+	}
+
+	class := strings.TrimSpace(strings.Join(classes, " "))
+	if class == "" {
+		return ""
+	}
+	return fmt.Sprintf(` class="%s"`, html.EscapeString(class))
+}
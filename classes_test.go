@@ -0,0 +1,68 @@
+package goeditorjs_test
+
+import (
+	"testing"
+
+	"github.com/davidscottmills/goeditorjs"
+	"github.com/stretchr/testify/require"
+)
+
+const classesTestData = `{"blocks": [
+	{"type": "header", "data": {"text": "Heading", "level": 2}},
+	{"type": "paragraph", "data": {"text": "paragraph", "alignment": "center"}},
+	{"type": "list", "data": {"style": "unordered", "items": ["one"]}},
+	{"type": "codeBox", "data": {"language": "go", "code": "x"}},
+	{"type": "table", "data": {"content": [["a"]]}},
+	{"type": "quote", "data": {"text": "q", "caption": "c"}},
+	{"type": "image", "data": {"file": {"url": "a.jpg"}, "caption": "", "stretched": true}}
+]}`
+
+func classesTestEngine(opts ...goeditorjs.HTMLEngineOptions) *goeditorjs.HTMLEngine {
+	eng := goeditorjs.NewHTMLEngine(opts...)
+	eng.RegisterBlockHandlers(
+		&goeditorjs.HeaderHandler{},
+		&goeditorjs.ParagraphHandler{},
+		&goeditorjs.ListHandler{},
+		&goeditorjs.CodeBoxHandler{},
+		&goeditorjs.TableHandler{},
+		&goeditorjs.QuoteHandler{},
+		&goeditorjs.ImageHandler{},
+	)
+	return eng
+}
+
+func Test_GenerateHTML_ClassMapNone(t *testing.T) {
+	result, err := classesTestEngine(goeditorjs.WithClassMap(goeditorjs.ClassMapNone)).GenerateHTML(classesTestData)
+	require.NoError(t, err)
+	require.Equal(t, `<h2>Heading</h2>`+
+		`<p style="text-align:center">paragraph</p>`+
+		`<ul><li>one</li></ul>`+
+		`<pre><code class="go">x</code></pre>`+
+		`<table><tr><td>a</td></tr></table>`+
+		`<blockquote>q<cite>c</cite></blockquote>`+
+		`<img src="a.jpg" alt="" class="image-tool--stretched"/>`, result)
+}
+
+func Test_GenerateHTML_ClassMap(t *testing.T) {
+	result, err := classesTestEngine(goeditorjs.WithClassMap(goeditorjs.ClassMapEditorJS)).GenerateHTML(classesTestData)
+	require.NoError(t, err)
+	require.Equal(t, `<h2 class="ce-header">Heading</h2>`+
+		`<p class="ce-paragraph cdx-block" style="text-align:center">paragraph</p>`+
+		`<ul class="cdx-list cdx-list--unordered"><li class="cdx-list__item">one</li></ul>`+
+		`<pre class="ce-code"><code class="go ce-code__textarea">x</code></pre>`+
+		`<table class="tc-table"><tr class="tc-row"><td class="tc-cell">a</td></tr></table>`+
+		`<blockquote class="cdx-quote">q<cite class="cdx-quote__caption">c</cite></blockquote>`+
+		`<img src="a.jpg" alt="" class="image-tool--stretched image-tool__image-picture"/>`, result)
+}
+
+func Test_GenerateHTML_ClassMap_Handler_Override(t *testing.T) {
+	eng := goeditorjs.NewHTMLEngine(goeditorjs.WithClassMap(goeditorjs.ClassMapTailwindTypography))
+	eng.RegisterBlockHandlers(&goeditorjs.HeaderHandler{Classes: goeditorjs.ClassMap{"h1": "title", "h2": ""}})
+	result, err := eng.GenerateHTML(`{"blocks": [
+		{"type": "header", "data": {"text": "One", "level": 1}},
+		{"type": "header", "data": {"text": "Two", "level": 2}},
+		{"type": "header", "data": {"text": "Three", "level": 3}}
+	]}`)
+	require.NoError(t, err)
+	require.Equal(t, `<h1 class="title">One</h1><h2>Two</h2><h3 class="text-xl font-semibold mt-8 mb-3">Three</h3>`, result)
+}
@@ -0,0 +1,303 @@
+// Command goeditorjs converts, validates and lints editor.js documents.
+//
+// Usage:
+//
+//	goeditorjs convert [-to html|markdown] [-dialect dialect] [-front-matter yaml|toml] [-unknown] [-document] [-lang lang]
+//		[-o output] [file]
+//	goeditorjs validate [-format text|json] [-allow-unknown] [file]
+//	goeditorjs lint [-format text|json] [-base dir] [-disable rule,...] [file]
+//
+// The document is read from stdin if no file or "-" is given.
+//
+// Exit codes: 0 on success, 1 if validate or lint found problems, 2 on usage, input or conversion errors.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/davidscottmills/goeditorjs"
+)
+
+const (
+	exitOK       = 0
+	exitProblems = 1
+	exitError    = 2
+)
+
+const usage = `usage: goeditorjs <command> [flags] [file]
+
+commands:
+  convert   convert the document to html or markdown
+  validate  report schema problems per block
+  lint      report content problems
+
+Run "goeditorjs <command> -h" for the flags of a command.
+`
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return exitError
+	}
+
+	switch args[0] {
+	case "convert":
+		return convert(args[1:], stdin, stdout, stderr)
+	case "validate":
+		return validate(args[1:], stdin, stdout, stderr)
+	case "lint":
+		return lint(args[1:], stdin, stdout, stderr)
+	case "-h", "-help", "--help", "help":
+		fmt.Fprint(stdout, usage)
+		return exitOK
+	}
+
+	fmt.Fprintf(stderr, "goeditorjs: unknown command %q\n\n%s", args[0], usage)
+	return exitError
+}
+
+func convert(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("convert", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	to := fs.String("to", "html", "output format: html or markdown")
+	unknown := fs.Bool("unknown", false, "render blocks without a handler as code instead of failing")
+	output := fs.String("o", "", "write the output to `file` instead of stdout")
+	document := fs.Bool("document", false, "render a complete html document with the default css")
+	lang := fs.String("lang", "", "`language` of the html document")
+	dialect := fs.String("dialect", "gfm", "markdown `dialect`: commonmark, gfm, multimarkdown, obsidian, hugo or jekyll")
+	frontMatter := fs.String("front-matter", "", "prepend front matter in `format` yaml or toml to the markdown")
+	if err := fs.Parse(args); err != nil {
+		return exitError
+	}
+
+	data, err := readInput(fs.Arg(0), stdin)
+	if err != nil {
+		fmt.Fprintf(stderr, "goeditorjs: %v\n", err)
+		return exitError
+	}
+
+	var result string
+	switch *to {
+	case "html":
+		engine := goeditorjs.NewHTMLEngine()
+		if *document {
+			engine.Document = &goeditorjs.HTMLDocumentOptions{Lang: *lang, DefaultCSS: true}
+		}
+		engine.RegisterBlockHandlers(htmlHandlers()...)
+		if *unknown {
+			result, err = engine.GenerateHTMLWithUnknownBlock(data)
+		} else {
+			result, err = engine.GenerateHTML(data)
+		}
+	case "markdown", "md":
+		markdownDialect := goeditorjs.MarkdownDialectByName(*dialect)
+		if markdownDialect == nil {
+			fmt.Fprintf(stderr, "goeditorjs: unknown markdown dialect %q\n", *dialect)
+			return exitError
+		}
+		engine := goeditorjs.NewMarkdownEngine(goeditorjs.WithMarkdownDialect(markdownDialect))
+		if *frontMatter != "" {
+			engine.FrontMatter = &goeditorjs.FrontMatterOptions{Format: goeditorjs.FrontMatterFormat(*frontMatter)}
+		}
+		engine.RegisterBlockHandlers(markdownHandlers()...)
+		if *unknown {
+			result, err = engine.GenerateMarkdownWithUnknownBlock(data)
+		} else {
+			result, err = engine.GenerateMarkdown(data)
+		}
+	default:
+		fmt.Fprintf(stderr, "goeditorjs: unknown output format %q\n", *to)
+		return exitError
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "goeditorjs: %v\n", err)
+		return exitError
+	}
+
+	if *output != "" {
+		err = ioutil.WriteFile(*output, []byte(result), 0644)
+	} else {
+		_, err = fmt.Fprintln(stdout, result)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "goeditorjs: %v\n", err)
+		return exitError
+	}
+	return exitOK
+}
+
+func validate(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("validate", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	format := fs.String("format", "text", "report format: text or json")
+	allowUnknown := fs.Bool("allow-unknown", false, "don't report blocks of unknown types")
+	if err := fs.Parse(args); err != nil {
+		return exitError
+	}
+
+	data, err := readInput(fs.Arg(0), stdin)
+	if err != nil {
+		fmt.Fprintf(stderr, "goeditorjs: %v\n", err)
+		return exitError
+	}
+
+	validator := goeditorjs.NewValidator()
+	validator.AllowUnknownBlocks = *allowUnknown
+	problems, err := validator.Validate(data)
+	if err != nil {
+		fmt.Fprintf(stderr, "goeditorjs: invalid document: %v\n", err)
+		return exitError
+	}
+
+	lines := make([]string, len(problems))
+	for i, p := range problems {
+		lines[i] = p.Error()
+	}
+	if err := report(stdout, *format, problems, lines); err != nil {
+		fmt.Fprintf(stderr, "goeditorjs: %v\n", err)
+		return exitError
+	}
+	if len(problems) > 0 {
+		return exitProblems
+	}
+	return exitOK
+}
+
+func lint(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("lint", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	format := fs.String("format", "text", "report format: text or json")
+	baseDir := fs.String("base", "", "`dir` relative links are resolved against (defaults to the directory of file)")
+	disable := fs.String("disable", "", "comma separated `rules` that aren't reported")
+	if err := fs.Parse(args); err != nil {
+		return exitError
+	}
+
+	file := fs.Arg(0)
+	data, err := readInput(file, stdin)
+	if err != nil {
+		fmt.Fprintf(stderr, "goeditorjs: %v\n", err)
+		return exitError
+	}
+
+	options := &goeditorjs.LintOptions{BaseDir: *baseDir}
+	if options.BaseDir == "" && file != "" && file != "-" {
+		options.BaseDir = filepath.Dir(file)
+	}
+	if *disable != "" {
+		options.DisabledRules = strings.Split(*disable, ",")
+	}
+
+	issues, err := goeditorjs.Lint(data, options)
+	if err != nil {
+		fmt.Fprintf(stderr, "goeditorjs: invalid document: %v\n", err)
+		return exitError
+	}
+
+	lines := make([]string, len(issues))
+	for i, issue := range issues {
+		lines[i] = issue.String()
+	}
+	if err := report(stdout, *format, issues, lines); err != nil {
+		fmt.Fprintf(stderr, "goeditorjs: %v\n", err)
+		return exitError
+	}
+	if len(issues) > 0 {
+		return exitProblems
+	}
+	return exitOK
+}
+
+// report writes v as indented json or lines as text
+func report(w io.Writer, format string, v interface{}, lines []string) error {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	case "text":
+		for _, line := range lines {
+			if _, err := fmt.Fprintln(w, line); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown report format %q", format)
+}
+
+func readInput(file string, stdin io.Reader) (string, error) {
+	var content []byte
+	var err error
+	if file == "" || file == "-" {
+		content, err = ioutil.ReadAll(stdin)
+	} else {
+		content, err = ioutil.ReadFile(file)
+	}
+	return string(content), err
+}
+
+func htmlHandlers() []goeditorjs.HTMLBlockHandler {
+	return []goeditorjs.HTMLBlockHandler{
+		&goeditorjs.HeaderHandler{},
+		&goeditorjs.ParagraphHandler{},
+		&goeditorjs.ListHandler{},
+		&goeditorjs.ChecklistHandler{},
+		&goeditorjs.CodeBoxHandler{},
+		&goeditorjs.CodeHandler{},
+		&goeditorjs.RawHTMLHandler{},
+		&goeditorjs.ImageHandler{},
+		&goeditorjs.SimpleImageHandler{},
+		&goeditorjs.TableHandler{},
+		&goeditorjs.QuoteHandler{},
+		&goeditorjs.DelimiterHandler{},
+		&goeditorjs.WarningHandler{},
+		&goeditorjs.AlertHandler{},
+		&goeditorjs.LinkToolHandler{},
+		&goeditorjs.AttachesHandler{},
+		&goeditorjs.MathHandler{},
+		&goeditorjs.MermaidHandler{},
+		&goeditorjs.ColumnsHandler{},
+		&goeditorjs.ToggleHandler{},
+		&goeditorjs.MediaHandler{Kind: goeditorjs.MediaAudio},
+		&goeditorjs.MediaHandler{Kind: goeditorjs.MediaVideo},
+	}
+}
+
+func markdownHandlers() []goeditorjs.MarkdownBlockHandler {
+	return []goeditorjs.MarkdownBlockHandler{
+		&goeditorjs.HeaderHandler{},
+		&goeditorjs.ParagraphHandler{},
+		&goeditorjs.ListHandler{},
+		&goeditorjs.ChecklistHandler{},
+		&goeditorjs.CodeBoxHandler{},
+		&goeditorjs.CodeHandler{},
+		&goeditorjs.RawHTMLHandler{},
+		&goeditorjs.ImageHandler{},
+		&goeditorjs.SimpleImageHandler{},
+		&goeditorjs.TableHandler{},
+		&goeditorjs.QuoteHandler{},
+		&goeditorjs.DelimiterHandler{},
+		&goeditorjs.WarningHandler{},
+		&goeditorjs.AlertHandler{},
+		&goeditorjs.LinkToolHandler{},
+		&goeditorjs.AttachesHandler{},
+		&goeditorjs.MathHandler{},
+		&goeditorjs.MermaidHandler{},
+		&goeditorjs.ColumnsHandler{},
+		&goeditorjs.ToggleHandler{},
+		&goeditorjs.MediaHandler{Kind: goeditorjs.MediaAudio},
+		&goeditorjs.MediaHandler{Kind: goeditorjs.MediaVideo},
+	}
+}
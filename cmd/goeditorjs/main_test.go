@@ -0,0 +1,46 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_run_ExitCodes(t *testing.T) {
+	valid := `{"blocks": [{"type": "header", "data": {"text": "Heading", "level": 1}}]}`
+	invalid := `{"blocks": [{"type": "header", "data": {"text": "Heading", "level": 9}}]}`
+	testData := []struct {
+		args     []string
+		input    string
+		expected int
+	}{
+		{args: []string{}, expected: exitError},
+		{args: []string{"unknown"}, expected: exitError},
+		{args: []string{"convert"}, input: valid, expected: exitOK},
+		{args: []string{"convert", "-to", "markdown"}, input: valid, expected: exitOK},
+		{args: []string{"convert", "-to", "markdown", "-dialect", "hugo"}, input: valid, expected: exitOK},
+		{args: []string{"convert", "-to", "markdown", "-dialect", "unknown"}, input: valid, expected: exitError},
+		{args: []string{"convert", "-to", "markdown", "-front-matter", "toml"}, input: valid, expected: exitOK},
+		{args: []string{"convert", "-to", "markdown", "-front-matter", "json"}, input: valid, expected: exitError},
+		{args: []string{"convert"}, input: `{`, expected: exitError},
+		{args: []string{"validate"}, input: valid, expected: exitOK},
+		{args: []string{"validate", "-format", "json"}, input: invalid, expected: exitProblems},
+		{args: []string{"lint"}, input: valid, expected: exitOK},
+		{args: []string{"lint"}, input: `{"blocks": [{"type": "paragraph", "data": {"text": ""}}]}`, expected: exitProblems},
+	}
+
+	for _, td := range testData {
+		stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+		code := run(td.args, strings.NewReader(td.input), stdout, stderr)
+		require.Equal(t, td.expected, code, "args: %v, stderr: %s", td.args, stderr.String())
+	}
+}
+
+func Test_run_Convert_Output(t *testing.T) {
+	stdout := &bytes.Buffer{}
+	code := run([]string{"convert", "-to", "markdown"}, strings.NewReader(`{"blocks": [{"type": "header", "data": {"text": "Heading", "level": 2}}]}`), stdout, &bytes.Buffer{})
+	require.Equal(t, exitOK, code)
+	require.Equal(t, "## Heading\n", stdout.String())
+}
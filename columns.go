@@ -0,0 +1,83 @@
+package goeditorjs
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ColumnsHandler is the default ColumnsHandler for EditorJS HTML and markdown generation of the columns of the
+// editorjs-columns tool. Every column is a nested EditorJS document rendered by the engine generating the block.
+type ColumnsHandler struct {
+	// Classes override the engine's ClassMap for the elements this handler emits. The keys are "columns" and
+	// "columns__column".
+	Classes ClassMap
+}
+
+func (*ColumnsHandler) parse(editorJSBlock EditorJSBlock) (*Columns, error) {
+	columns := &Columns{}
+	return columns, json.Unmarshal(editorJSBlock.Data, columns)
+}
+
+// Type "columns"
+func (*ColumnsHandler) Type() string {
+	return "columns"
+}
+
+// GenerateHTML returns ErrEngineRequired, the columns are rendered by GenerateHTMLWithEngine
+func (h *ColumnsHandler) GenerateHTML(editorJSBlock EditorJSBlock) (string, error) {
+	return h.GenerateHTMLWithEngine(editorJSBlock, nil)
+}
+
+// GenerateHTMLWithEngine generates a grid of the columns of ColumnsBlocks using the engine's handlers
+func (h *ColumnsHandler) GenerateHTMLWithEngine(editorJSBlock EditorJSBlock, engine *HTMLEngine) (string, error) {
+	if engine == nil {
+		return "", ErrEngineRequired
+	}
+	columns, err := h.parse(editorJSBlock)
+	if err != nil {
+		return "", err
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("<div%s>", classAttr(h.Classes, engine, "columns", "columns")))
+	for _, col := range columns.Cols {
+		html, err := engine.GenerateBlocksHTML(col.Blocks)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(fmt.Sprintf("<div%s>%s</div>", classAttr(h.Classes, engine, "columns__column", "columns__column"), html))
+	}
+	sb.WriteString("</div>")
+
+	return sb.String(), nil
+}
+
+// GenerateMarkdown returns ErrEngineRequired, the columns are rendered by GenerateMarkdownWithEngine
+func (h *ColumnsHandler) GenerateMarkdown(editorJSBlock EditorJSBlock) (string, error) {
+	return h.GenerateMarkdownWithEngine(editorJSBlock, nil)
+}
+
+// GenerateMarkdownWithEngine generates the columns of ColumnsBlocks one after another using the engine's handlers
+func (h *ColumnsHandler) GenerateMarkdownWithEngine(editorJSBlock EditorJSBlock, engine *MarkdownEngine) (string, error) {
+	if engine == nil {
+		return "", ErrEngineRequired
+	}
+	columns, err := h.parse(editorJSBlock)
+	if err != nil {
+		return "", err
+	}
+
+	sections := []string{}
+	for _, col := range columns.Cols {
+		md, err := engine.GenerateBlocksMarkdown(col.Blocks)
+		if err != nil {
+			return "", err
+		}
+		if md != "" {
+			sections = append(sections, md)
+		}
+	}
+
+	return strings.Join(sections, "\n\n"), nil
+}
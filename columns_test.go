@@ -0,0 +1,76 @@
+package goeditorjs_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/davidscottmills/goeditorjs"
+	"github.com/stretchr/testify/require"
+)
+
+const columnsData = `{"blocks": [{"type": "columns", "data": {"cols": [
+	{"time": 1, "blocks": [{"type": "header", "data": {"text": "Left", "level": 2}}, {"type": "paragraph", "data": {"text": "One", "alignment": "left"}}]},
+	{"time": 1, "blocks": []},
+	{"time": 1, "blocks": [{"type": "columns", "data": {"cols": [{"blocks": [{"type": "paragraph", "data": {"text": "Nested", "alignment": "left"}}]}]}}]}
+]}}]}`
+
+func Test_ColumnsHandler_Type(t *testing.T) {
+	h := &goeditorjs.ColumnsHandler{}
+	require.Equal(t, "columns", h.Type())
+}
+
+func Test_ColumnsHandler_Requires_Engine(t *testing.T) {
+	h := &goeditorjs.ColumnsHandler{}
+	_, err := h.GenerateHTML(goeditorjs.EditorJSBlock{Type: "columns", Data: []byte(`{"cols": []}`)})
+	require.True(t, errors.Is(err, goeditorjs.ErrEngineRequired))
+	_, err = h.GenerateMarkdown(goeditorjs.EditorJSBlock{Type: "columns", Data: []byte(`{"cols": []}`)})
+	require.True(t, errors.Is(err, goeditorjs.ErrEngineRequired))
+}
+
+func Test_ColumnsHandler_GenerateHTML(t *testing.T) {
+	eng := goeditorjs.NewHTMLEngine()
+	eng.RegisterBlockHandlers(&goeditorjs.ColumnsHandler{}, &goeditorjs.HeaderHandler{}, &goeditorjs.ParagraphHandler{})
+	result, err := eng.GenerateHTML(columnsData)
+	require.NoError(t, err)
+	require.Equal(t, `<div class="columns">`+
+		`<div class="columns__column"><h2>Left</h2><p>One</p></div>`+
+		`<div class="columns__column"></div>`+
+		`<div class="columns__column"><div class="columns"><div class="columns__column"><p>Nested</p></div></div></div>`+
+		`</div>`, result)
+
+	eng = goeditorjs.NewHTMLEngine()
+	eng.RegisterBlockHandlers(&goeditorjs.ColumnsHandler{}, &goeditorjs.ParagraphHandler{})
+	_, err = eng.GenerateHTML(columnsData)
+	require.True(t, errors.Is(err, goeditorjs.ErrBlockHandlerNotFound))
+}
+
+func Test_ColumnsHandler_GenerateMarkdown(t *testing.T) {
+	eng := goeditorjs.NewMarkdownEngine()
+	eng.RegisterBlockHandlers(&goeditorjs.ColumnsHandler{}, &goeditorjs.HeaderHandler{}, &goeditorjs.ParagraphHandler{})
+	result, err := eng.GenerateMarkdown(columnsData)
+	require.NoError(t, err)
+	require.Equal(t, "## Left\n\nOne\n\nNested", result)
+
+	_, err = eng.GenerateMarkdown(`{"blocks": [{"type": "columns", "data": {"cols": [{"blocks": [{"type": "missing", "data": {}}]}]}}]}`)
+	require.True(t, errors.Is(err, goeditorjs.ErrBlockHandlerNotFound))
+}
+
+func Test_ColumnsHandler_Unknown_Blocks(t *testing.T) {
+	data := `{"blocks": [{"type": "columns", "data": {"cols": [{"blocks": [
+		{"type": "paragraph", "data": {"text": "One", "alignment": "left"}},
+		{"type": "missing", "data": {}}
+	]}]}}]}`
+
+	htmlEngine := goeditorjs.NewHTMLEngine()
+	htmlEngine.RegisterBlockHandlers(&goeditorjs.ColumnsHandler{}, &goeditorjs.ParagraphHandler{})
+	result, err := htmlEngine.GenerateHTMLWithUnknownBlock(data)
+	require.NoError(t, err)
+	require.Equal(t, `<div class="columns"><div class="columns__column"><p>One</p>`+
+		`<pre><code>// type: missing</code><code>{}</code></pre></div></div>`, result)
+
+	markdownEngine := goeditorjs.NewMarkdownEngine()
+	markdownEngine.RegisterBlockHandlers(&goeditorjs.ColumnsHandler{}, &goeditorjs.ParagraphHandler{})
+	result, err = markdownEngine.GenerateMarkdownWithUnknownBlock(data)
+	require.NoError(t, err)
+	require.Equal(t, "One\n\n```json\n// type: missing\n{}\n```", result)
+}
@@ -0,0 +1,29 @@
+package goeditorjs
+
+import "fmt"
+
+// DelimiterHandler is the default DelimiterHandler for EditorJS HTML and markdown generation
+type DelimiterHandler struct {
+	// Classes override the engine's ClassMap for the elements this handler emits
+	Classes ClassMap
+}
+
+// Type "delimiter"
+func (*DelimiterHandler) Type() string {
+	return "delimiter"
+}
+
+// GenerateHTML generates html for DelimiterBlocks
+func (h *DelimiterHandler) GenerateHTML(editorJSBlock EditorJSBlock) (string, error) {
+	return h.GenerateHTMLWithEngine(editorJSBlock, nil)
+}
+
+// GenerateHTMLWithEngine generates html for DelimiterBlocks using the engine's ClassMap
+func (h *DelimiterHandler) GenerateHTMLWithEngine(editorJSBlock EditorJSBlock, engine *HTMLEngine) (string, error) {
+	return fmt.Sprintf("<hr%s/>", classAttr(h.Classes, engine, "hr")), nil
+}
+
+// GenerateMarkdown generates markdown for DelimiterBlocks
+func (h *DelimiterHandler) GenerateMarkdown(editorJSBlock EditorJSBlock) (string, error) {
+	return "---", nil
+}
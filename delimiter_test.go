@@ -0,0 +1,27 @@
+package goeditorjs_test
+
+import (
+	"testing"
+
+	"github.com/davidscottmills/goeditorjs"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DelimiterHandler_Type(t *testing.T) {
+	h := &goeditorjs.DelimiterHandler{}
+	require.Equal(t, "delimiter", h.Type())
+}
+
+func Test_DelimiterHandler_GenerateHTML(t *testing.T) {
+	h := &goeditorjs.DelimiterHandler{Classes: goeditorjs.ClassMap{"hr": "my-8"}}
+	result, err := h.GenerateHTML(goeditorjs.EditorJSBlock{Type: "delimiter", Data: []byte(`{}`)})
+	require.NoError(t, err)
+	require.Equal(t, `<hr class="my-8"/>`, result)
+}
+
+func Test_DelimiterHandler_GenerateMarkdown(t *testing.T) {
+	h := &goeditorjs.DelimiterHandler{}
+	result, err := h.GenerateMarkdown(goeditorjs.EditorJSBlock{Type: "delimiter", Data: []byte(`{}`)})
+	require.NoError(t, err)
+	require.Equal(t, "---", result)
+}
@@ -0,0 +1,101 @@
+package goeditorjs
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// diagramLanguages are the normalized code block languages rendered as diagrams instead of code
+var diagramLanguages = map[string]bool{"mermaid": true, "plantuml": true, "graphviz": true}
+
+// DiagramRenderer renders diagrams for HTML output, e.g. by calling a kroki or PlantUML server
+type DiagramRenderer interface {
+	// RenderDiagram returns the diagram source of the normalized language ("mermaid", "plantuml" or "graphviz") as
+	// HTML. The result is inserted into the output as is, so it must be escaped by the DiagramRenderer.
+	RenderDiagram(source, language string) (string, error)
+}
+
+// DiagramRendererFunc adapts a function to a DiagramRenderer
+type DiagramRendererFunc func(source, language string) (string, error)
+
+// RenderDiagram calls f(source, language)
+func (f DiagramRendererFunc) RenderDiagram(source, language string) (string, error) {
+	return f(source, language)
+}
+
+// WithDiagramRenderer sets the DiagramRenderer of the HTMLEngine
+func WithDiagramRenderer(renderer DiagramRenderer) HTMLEngineOptions {
+	return func(h *HTMLEngine) {
+		h.DiagramRenderer = renderer
+	}
+}
+
+// renderDiagram renders source with the engine's DiagramRenderer or, without one, as escaped source in a pre element
+// with the language as class, which is what mermaid.js looks for
+func renderDiagram(source, language string, classes ClassMap, engine *HTMLEngine) (string, error) {
+	if engine != nil && engine.DiagramRenderer != nil {
+		return engine.DiagramRenderer.RenderDiagram(source, language)
+	}
+	return fmt.Sprintf(`<pre%s>%s</pre>`, classAttr(classes, engine, language, language), escapeCode(source)), nil
+}
+
+// MermaidHandler is the default MermaidHandler for EditorJS HTML and markdown generation of mermaid blocks
+type MermaidHandler struct {
+	// Classes override the engine's ClassMap for the elements this handler emits. The keys are "mermaid", "figure"
+	// and "figcaption".
+	Classes ClassMap
+}
+
+func (*MermaidHandler) parse(editorJSBlock EditorJSBlock) (*Mermaid, error) {
+	mermaid := &Mermaid{}
+	if err := json.Unmarshal(editorJSBlock.Data, mermaid); err != nil {
+		return nil, err
+	}
+	mermaid.Code = strings.TrimRight(mermaid.Code, "\n")
+	return mermaid, nil
+}
+
+// Type "mermaid"
+func (*MermaidHandler) Type() string {
+	return "mermaid"
+}
+
+// GenerateHTML generates html for MermaidBlocks
+func (h *MermaidHandler) GenerateHTML(editorJSBlock EditorJSBlock) (string, error) {
+	return h.GenerateHTMLWithEngine(editorJSBlock, nil)
+}
+
+// GenerateHTMLWithEngine generates html for MermaidBlocks using the engine's ClassMap and DiagramRenderer
+func (h *MermaidHandler) GenerateHTMLWithEngine(editorJSBlock EditorJSBlock, engine *HTMLEngine) (string, error) {
+	mermaid, err := h.parse(editorJSBlock)
+	if err != nil {
+		return "", err
+	}
+
+	diagram, err := renderDiagram(mermaid.Code, "mermaid", h.Classes, engine)
+	if err != nil || strings.TrimSpace(mermaid.Caption) == "" {
+		return diagram, err
+	}
+	figcaption := fmt.Sprintf(`<figcaption%s>%s</figcaption>`, classAttr(h.Classes, engine, "figcaption"), mermaid.Caption)
+	return fmt.Sprintf(`<figure%s>%s%s</figure>`, classAttr(h.Classes, engine, "figure"), diagram, figcaption), nil
+}
+
+// GenerateMarkdown generates markdown for MermaidBlocks
+func (h *MermaidHandler) GenerateMarkdown(editorJSBlock EditorJSBlock) (string, error) {
+	return h.GenerateMarkdownWithEngine(editorJSBlock, nil)
+}
+
+// GenerateMarkdownWithEngine generates markdown for MermaidBlocks in the engine's dialect
+func (h *MermaidHandler) GenerateMarkdownWithEngine(editorJSBlock EditorJSBlock, engine *MarkdownEngine) (string, error) {
+	mermaid, err := h.parse(editorJSBlock)
+	if err != nil {
+		return "", err
+	}
+
+	md := codeFence(mermaid.Code, "mermaid")
+	if caption := markdownInline(mermaid.Caption, engine.dialect()); strings.TrimSpace(caption) != "" {
+		md += "\n\n" + caption
+	}
+	return md, nil
+}
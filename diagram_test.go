@@ -0,0 +1,77 @@
+package goeditorjs_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/davidscottmills/goeditorjs"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_MermaidHandler_Type(t *testing.T) {
+	h := &goeditorjs.MermaidHandler{}
+	require.Equal(t, "mermaid", h.Type())
+}
+
+func Test_MermaidHandler_Returns_Parse_Err(t *testing.T) {
+	h := &goeditorjs.MermaidHandler{}
+	_, err := h.GenerateHTML(goeditorjs.EditorJSBlock{Type: "mermaid", Data: []byte{}})
+	require.Error(t, err)
+	_, err = h.GenerateMarkdown(goeditorjs.EditorJSBlock{Type: "mermaid", Data: []byte{}})
+	require.Error(t, err)
+}
+
+func Test_MermaidHandler_GenerateHTML(t *testing.T) {
+	h := &goeditorjs.MermaidHandler{}
+	result, err := h.GenerateHTML(goeditorjs.EditorJSBlock{Type: "mermaid", Data: []byte(`{"code": "graph TD\n  A-->B\n"}`)})
+	require.NoError(t, err)
+	require.Equal(t, "<pre class=\"mermaid\">graph TD\n  A--&gt;B</pre>", result)
+
+	result, err = h.GenerateHTML(goeditorjs.EditorJSBlock{Type: "mermaid", Data: []byte(`{"code": "graph TD", "caption": "<b>Flow</b>"}`)})
+	require.NoError(t, err)
+	require.Equal(t, `<figure><pre class="mermaid">graph TD</pre><figcaption><b>Flow</b></figcaption></figure>`, result)
+}
+
+func Test_MermaidHandler_GenerateMarkdown(t *testing.T) {
+	h := &goeditorjs.MermaidHandler{}
+	result, err := h.GenerateMarkdown(goeditorjs.EditorJSBlock{Type: "mermaid", Data: []byte(`{"code": "graph TD\n  A-->B", "caption": "Flow"}`)})
+	require.NoError(t, err)
+	require.Equal(t, "```mermaid\ngraph TD\n  A-->B\n```\n\nFlow", result)
+}
+
+func Test_CodeBoxHandler_Diagrams(t *testing.T) {
+	h := &goeditorjs.CodeBoxHandler{}
+	testData := []struct {
+		language     string
+		expectedHTML string
+		expectedMD   string
+	}{
+		{language: "mermaid", expectedHTML: `<pre class="mermaid">a -&gt; b</pre>`, expectedMD: "```mermaid\na -> b\n```"},
+		{language: "puml", expectedHTML: `<pre class="plantuml">a -&gt; b</pre>`, expectedMD: "```plantuml\na -> b\n```"},
+		{language: "dot {1}", expectedHTML: `<pre class="graphviz">a -&gt; b</pre>`, expectedMD: "```graphviz\na -> b\n```"},
+	}
+
+	for _, td := range testData {
+		block := goeditorjs.EditorJSBlock{Type: "codeBox", Data: []byte(fmt.Sprintf(`{"code": "a -&gt; b", "language": %q}`, td.language))}
+		result, err := h.GenerateHTML(block)
+		require.NoError(t, err)
+		require.Equal(t, td.expectedHTML, result)
+		result, err = h.GenerateMarkdown(block)
+		require.NoError(t, err)
+		require.Equal(t, td.expectedMD, result)
+	}
+}
+
+func Test_HTMLEngine_DiagramRenderer(t *testing.T) {
+	eng := goeditorjs.NewHTMLEngine(goeditorjs.WithDiagramRenderer(goeditorjs.DiagramRendererFunc(func(source, language string) (string, error) {
+		return fmt.Sprintf(`<svg data-language="%s">%d</svg>`, language, len(source)), nil
+	})))
+	eng.RegisterBlockHandlers(&goeditorjs.MermaidHandler{}, &goeditorjs.CodeHandler{})
+	result, err := eng.GenerateHTML(`{"blocks": [
+		{"type": "mermaid", "data": {"code": "graph TD"}},
+		{"type": "code", "data": {"code": "digraph {}", "language": "graphviz"}},
+		{"type": "code", "data": {"code": "x", "language": "go"}}
+	]}`)
+	require.NoError(t, err)
+	require.Equal(t, `<svg data-language="mermaid">8</svg><svg data-language="graphviz">10</svg><pre><code class="go">x</code></pre>`, result)
+}
@@ -0,0 +1,122 @@
+package goeditorjs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MarkdownDialect describes the markdown flavor generated by the MarkdownEngine. The built-in handlers fall back to
+// other syntax for the features a dialect lacks, and to html only if the dialect permits it.
+type MarkdownDialect struct {
+	// Name identifies the dialect, e.g. in the -dialect flag of the command line tool
+	Name string
+	// Tables enables pipe tables
+	Tables bool
+	// TaskLists enables task list items: "- [x] done"
+	TaskLists bool
+	// Footnotes enables footnote references and definitions: "[^1]"
+	Footnotes bool
+	// HTML permits raw html for what the dialect can't express, like aligned paragraphs or images with classes
+	HTML bool
+	// Attributes is the syntax of the attribute lists used for classes and alignment instead of html
+	Attributes AttributeSyntax
+	// Admonitions is the syntax of warnings and alerts whose handler has no style of its own
+	Admonitions AdmonitionStyle
+}
+
+// AttributeSyntax is the syntax of block attribute lists, which set the classes and attributes of the preceding
+// block
+type AttributeSyntax string
+
+const (
+	// AttributesNone doesn't emit attribute lists
+	AttributesNone AttributeSyntax = ""
+	// AttributesGoldmark uses the block attributes of goldmark, the markdown parser of Hugo: "{.class}"
+	AttributesGoldmark AttributeSyntax = "goldmark"
+	// AttributesKramdown uses the inline attribute lists of kramdown, the markdown parser of Jekyll: "{: .class}"
+	AttributesKramdown AttributeSyntax = "kramdown"
+)
+
+var (
+	// MarkdownCommonMark is strict CommonMark, without extensions and without html
+	MarkdownCommonMark = &MarkdownDialect{Name: "commonmark", Admonitions: AdmonitionBlockquote}
+	// MarkdownGFM is GitHub Flavored Markdown. It is used if the MarkdownEngine has no dialect.
+	MarkdownGFM = &MarkdownDialect{Name: "gfm", Tables: true, TaskLists: true, Footnotes: true, HTML: true,
+		Admonitions: AdmonitionGFM}
+	// MarkdownMultiMarkdown is MultiMarkdown 6
+	MarkdownMultiMarkdown = &MarkdownDialect{Name: "multimarkdown", Tables: true, Footnotes: true, HTML: true,
+		Admonitions: AdmonitionBlockquote}
+	// MarkdownObsidian is the markdown of Obsidian notes
+	MarkdownObsidian = &MarkdownDialect{Name: "obsidian", Tables: true, TaskLists: true, Footnotes: true, HTML: true,
+		Admonitions: AdmonitionObsidian}
+	// MarkdownHugo is the markdown of Hugo with its default goldmark configuration, which drops raw html. Block
+	// attributes have to be enabled with markup.goldmark.parser.attribute.block.
+	MarkdownHugo = &MarkdownDialect{Name: "hugo", Tables: true, TaskLists: true, Footnotes: true,
+		Attributes: AttributesGoldmark, Admonitions: AdmonitionGFM}
+	// MarkdownJekyll is the markdown of Jekyll, which uses kramdown with its GFM parser
+	MarkdownJekyll = &MarkdownDialect{Name: "jekyll", Tables: true, TaskLists: true, Footnotes: true, HTML: true,
+		Attributes: AttributesKramdown, Admonitions: AdmonitionBlockquote}
+)
+
+// markdownDialects are the predefined dialects by name
+var markdownDialects = []*MarkdownDialect{
+	MarkdownCommonMark, MarkdownGFM, MarkdownMultiMarkdown, MarkdownObsidian, MarkdownHugo, MarkdownJekyll,
+}
+
+// MarkdownDialectByName returns the predefined dialect named name, or nil if there is none
+func MarkdownDialectByName(name string) *MarkdownDialect {
+	for _, d := range markdownDialects {
+		if strings.EqualFold(d.Name, name) {
+			return d
+		}
+	}
+	return nil
+}
+
+// WithMarkdownDialect sets the dialect of the MarkdownEngine
+func WithMarkdownDialect(dialect *MarkdownDialect) MarkdownEngineOptions {
+	return func(m *MarkdownEngine) {
+		m.Dialect = dialect
+	}
+}
+
+// dialect returns the dialect of the engine, MarkdownGFM if the engine or its dialect is nil
+func (markdownEngine *MarkdownEngine) dialect() *MarkdownDialect {
+	if markdownEngine == nil || markdownEngine.Dialect == nil {
+		return MarkdownGFM
+	}
+	return markdownEngine.Dialect
+}
+
+// admonitions returns style, or the admonition style of the dialect if style is AdmonitionDefault
+func (d *MarkdownDialect) admonitions(style AdmonitionStyle) AdmonitionStyle {
+	if style != AdmonitionDefault {
+		return style
+	}
+	return d.Admonitions
+}
+
+// attributes returns the attribute list of classes and style to put on the line following a block, or "" if the
+// dialect has no attribute lists or there is nothing to set
+func (d *MarkdownDialect) attributes(classes []string, style string) string {
+	attrs := []string{}
+	for _, class := range classes {
+		if class != "" {
+			attrs = append(attrs, "."+class)
+		}
+	}
+	if style != "" {
+		attrs = append(attrs, fmt.Sprintf("style=%q", style))
+	}
+	if len(attrs) == 0 {
+		return ""
+	}
+
+	switch d.Attributes {
+	case AttributesGoldmark:
+		return "{" + strings.Join(attrs, " ") + "}"
+	case AttributesKramdown:
+		return "{: " + strings.Join(attrs, " ") + "}"
+	}
+	return ""
+}
@@ -0,0 +1,123 @@
+package goeditorjs_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/davidscottmills/goeditorjs"
+	"github.com/stretchr/testify/require"
+)
+
+const dialectData = `{"blocks": [
+	{"type": "paragraph", "data": {"text": "Centered<sup data-id=\"a\">1</sup>", "alignment": "center"},
+		"tunes": {"footnotes": [{"id": "a", "content": "Note"}]}},
+	{"type": "table", "data": {"content": [["a", "b"], ["1", "2"]]}},
+	{"type": "checklist", "data": {"items": [{"text": "done", "checked": true}, {"text": "todo"}]}},
+	{"type": "image", "data": {"file": {"url": "a.png"}, "caption": "A", "stretched": true}},
+	{"type": "warning", "data": {"title": "Careful", "message": "Hot"}},
+	{"type": "raw", "data": {"html": "<br>"}}
+]}`
+
+func markdownDialectEngine(dialect *goeditorjs.MarkdownDialect) *goeditorjs.MarkdownEngine {
+	eng := goeditorjs.NewMarkdownEngine(goeditorjs.WithMarkdownDialect(dialect))
+	eng.RegisterBlockHandlers(&goeditorjs.ParagraphHandler{}, &goeditorjs.TableHandler{}, &goeditorjs.ChecklistHandler{},
+		&goeditorjs.ImageHandler{}, &goeditorjs.WarningHandler{}, &goeditorjs.RawHTMLHandler{})
+	return eng
+}
+
+func Test_MarkdownDialectByName(t *testing.T) {
+	require.Equal(t, goeditorjs.MarkdownHugo, goeditorjs.MarkdownDialectByName("Hugo"))
+	require.Nil(t, goeditorjs.MarkdownDialectByName("unknown"))
+}
+
+func Test_MarkdownEngine_Dialects(t *testing.T) {
+	testData := []struct {
+		dialect  *goeditorjs.MarkdownDialect
+		expected string
+	}{
+		{dialect: nil, expected: `<p style="text-align:center">Centered[^1]</p>` + "\n\n" +
+			"| a | b |\n| --- | --- |\n| 1 | 2 |\n\n\n" +
+			"- [x] done\n- [ ] todo\n\n" +
+			`<img src="a.png" alt="A" class="image-tool--stretched"/>` + "\n\n" +
+			"> [!WARNING]\n> **Careful**\n> Hot\n\n" +
+			"<br>\n\n" +
+			"[^1]: Note"},
+		{dialect: goeditorjs.MarkdownCommonMark, expected: "Centered[1]\n\n" +
+			"- a | b\n- 1 | 2\n\n" +
+			"- ☑ done\n- ☐ todo\n\n" +
+			"![A](a.png)\n\n" +
+			"> **Careful**\n>\n> Hot\n\n" +
+			"```html\n<br>\n```\n\n" +
+			"1. Note"},
+		{dialect: goeditorjs.MarkdownObsidian, expected: `<p style="text-align:center">Centered[^1]</p>` + "\n\n" +
+			"| a | b |\n| --- | --- |\n| 1 | 2 |\n\n\n" +
+			"- [x] done\n- [ ] todo\n\n" +
+			`<img src="a.png" alt="A" class="image-tool--stretched"/>` + "\n\n" +
+			"> [!warning] Careful\n> Hot\n\n" +
+			"<br>\n\n" +
+			"[^1]: Note"},
+		{dialect: goeditorjs.MarkdownHugo, expected: "Centered[^1]\n{style=\"text-align:center\"}\n\n" +
+			"| a | b |\n| --- | --- |\n| 1 | 2 |\n\n\n" +
+			"- [x] done\n- [ ] todo\n\n" +
+			"![A](a.png)\n{.image-tool--stretched}\n\n" +
+			"> [!WARNING]\n> **Careful**\n> Hot\n\n" +
+			"```html\n<br>\n```\n\n" +
+			"[^1]: Note"},
+		{dialect: goeditorjs.MarkdownJekyll, expected: "Centered[^1]\n{: style=\"text-align:center\"}\n\n" +
+			"| a | b |\n| --- | --- |\n| 1 | 2 |\n\n\n" +
+			"- [x] done\n- [ ] todo\n\n" +
+			"![A](a.png)\n{: .image-tool--stretched}\n\n" +
+			"> **Careful**\n>\n> Hot\n\n" +
+			"<br>\n\n" +
+			"[^1]: Note"},
+	}
+
+	for _, td := range testData {
+		result, err := markdownDialectEngine(td.dialect).GenerateMarkdown(dialectData)
+		require.NoError(t, err)
+		require.Equal(t, td.expected, result)
+	}
+}
+
+func Test_MarkdownEngine_Dialect_HTMLTable(t *testing.T) {
+	dialect := &goeditorjs.MarkdownDialect{HTML: true}
+	result, err := markdownDialectEngine(dialect).GenerateMarkdown(`{"blocks": [{"type": "table", "data": {"content": [["a"]]}}]}`)
+	require.NoError(t, err)
+	require.Equal(t, "<table><tr><td>a</td></tr></table>", result)
+}
+
+func Test_WarningHandler_Style_Overrides_Dialect(t *testing.T) {
+	eng := goeditorjs.NewMarkdownEngine(goeditorjs.WithMarkdownDialect(goeditorjs.MarkdownObsidian))
+	eng.RegisterBlockHandlers(&goeditorjs.WarningHandler{Style: goeditorjs.AdmonitionMkDocs})
+	result, err := eng.GenerateMarkdown(`{"blocks": [{"type": "warning", "data": {"title": "", "message": "Hot"}}]}`)
+	require.NoError(t, err)
+	require.Equal(t, "!!! warning\n\n    Hot", result)
+}
+
+func Test_MarkdownEngine_Dialect_InlineTags(t *testing.T) {
+	data := `{"blocks": [
+		{"type": "paragraph", "data": {"text": "<b>Bold</b>, <strong>strong </strong>and <i>italic<br></i>text"}},
+		{"type": "paragraph", "data": {"text": "<u>Under</u> <mark class=\"x\">marked</mark> <a>anchor</a> <b></b>empty<sup data-id=\"x\">?</sup>"}},
+		{"type": "list", "data": {"style": "unordered", "items": ["<em>one</em>", "<s>two</s>"]}},
+		{"type": "table", "data": {"content": [["<b>a</b>", "<u>b</u>"]]}}
+	]}`
+
+	eng := goeditorjs.NewMarkdownEngine(goeditorjs.WithMarkdownDialect(goeditorjs.MarkdownCommonMark))
+	eng.RegisterBlockHandlers(&goeditorjs.ParagraphHandler{}, &goeditorjs.ListHandler{}, &goeditorjs.TableHandler{})
+	result, err := eng.GenerateMarkdown(data)
+	require.NoError(t, err)
+	require.Equal(t, "**Bold**, **strong** and *italic*\\\ntext\n\n"+
+		"Under marked anchor empty?\n\n"+
+		"- *one*\n- two\n\n"+
+		"- **a** | b", result)
+	require.False(t, regexp.MustCompile(`</?[a-zA-Z]`).MatchString(result), result)
+
+	eng = goeditorjs.NewMarkdownEngine()
+	eng.RegisterBlockHandlers(&goeditorjs.ParagraphHandler{}, &goeditorjs.ListHandler{}, &goeditorjs.TableHandler{})
+	result, err = eng.GenerateMarkdown(data)
+	require.NoError(t, err)
+	require.Equal(t, "**Bold**, **strong** and *italic*\\\ntext\n\n"+
+		`<u>Under</u> <mark class="x">marked</mark> <a>anchor</a> empty<sup data-id="x">?</sup>`+"\n\n"+
+		"- *one*\n- <s>two</s>\n\n"+
+		"| **a** | <u>b</u> |\n| --- | --- |\n", result)
+}
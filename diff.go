@@ -0,0 +1,828 @@
+package goeditorjs
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// BlockChange is how a block changed between two versions of a document
+type BlockChange string
+
+const (
+	// BlockUnchanged blocks have the same type and data in both versions
+	BlockUnchanged BlockChange = "unchanged"
+	// BlockAdded blocks are only in the new version
+	BlockAdded BlockChange = "added"
+	// BlockRemoved blocks are only in the old version
+	BlockRemoved BlockChange = "removed"
+	// BlockModified blocks are in both versions, but their type, data or tunes changed
+	BlockModified BlockChange = "modified"
+)
+
+// DiffOp is the operation of a part of an inline diff
+type DiffOp int
+
+const (
+	// DiffEqual text is in both versions
+	DiffEqual DiffOp = iota
+	// DiffInsert text is only in the new version
+	DiffInsert
+	// DiffDelete text is only in the old version
+	DiffDelete
+)
+
+// WordDiff is a part of the word level diff of the text of a block
+type WordDiff struct {
+	Op   DiffOp
+	Text string
+}
+
+// BlockDiff is the change of a block between two versions of a document
+type BlockDiff struct {
+	Change BlockChange
+	// Moved is set if the block was moved relative to the other blocks of both versions. A moved block may be
+	// modified as well.
+	Moved bool
+	// Old is the block in the old version, nil for added blocks
+	Old *EditorJSBlock
+	// New is the block in the new version, nil for removed blocks
+	New *EditorJSBlock
+	// OldIndex is the index of the block in the old version, -1 for added blocks
+	OldIndex int
+	// NewIndex is the index of the block in the new version, -1 for removed blocks
+	NewIndex int
+	// Words is the word level diff of the plain text of modified blocks whose text changed
+	Words []WordDiff
+}
+
+// DocumentDiff is the difference between two versions of a document
+type DocumentDiff struct {
+	Old *Document
+	New *Document
+	// Blocks are the changes of the blocks in the order of the new version. Removed blocks follow the block preceding
+	// them in the old version.
+	Blocks []BlockDiff
+}
+
+// DiffOptions are the options available to Diff
+type DiffOptions struct {
+	// Similarity is the minimum similarity from 0 to 1 of two blocks without matching ids to be taken as versions of
+	// the same block. It is the share of words of text blocks and the share of data fields of other blocks that are
+	// equal.
+	Similarity float64
+}
+
+// DefaultDiffOptions are the default options available to Diff
+var DefaultDiffOptions = &DiffOptions{Similarity: 0.5}
+
+// Diff returns the difference between the old and the new version of a document. Blocks are aligned by their ids.
+// Blocks without an id on either side are aligned with the most similar block of the same type among the 64 nearest
+// ones. If options is nil, DefaultDiffOptions will be used.
+func Diff(oldDoc, newDoc *Document, options *DiffOptions) *DocumentDiff {
+	if options == nil {
+		options = DefaultDiffOptions
+	}
+	matches := alignBlocks(oldDoc.Blocks, newDoc.Blocks, options.Similarity)
+	moved := movedBlocks(matches, len(newDoc.Blocks))
+
+	diff := &DocumentDiff{Old: oldDoc, New: newDoc}
+	matched := make([]bool, len(oldDoc.Blocks))
+	for _, oi := range matches {
+		if oi >= 0 {
+			matched[oi] = true
+		}
+	}
+	nextOld := 0
+	addRemoved := func(until int) {
+		for ; nextOld < until; nextOld++ {
+			if !matched[nextOld] {
+				diff.Blocks = append(diff.Blocks, BlockDiff{Change: BlockRemoved, Old: &oldDoc.Blocks[nextOld],
+					OldIndex: nextOld, NewIndex: -1})
+			}
+		}
+	}
+
+	for ni := range newDoc.Blocks {
+		oi := matches[ni]
+		if oi < 0 {
+			diff.Blocks = append(diff.Blocks, BlockDiff{Change: BlockAdded, New: &newDoc.Blocks[ni], OldIndex: -1,
+				NewIndex: ni})
+			continue
+		}
+		if !moved[ni] {
+			addRemoved(oi)
+		}
+		blockDiff := BlockDiff{Change: BlockUnchanged, Moved: moved[ni], Old: &oldDoc.Blocks[oi],
+			New: &newDoc.Blocks[ni], OldIndex: oi, NewIndex: ni}
+		if !equalBlocks(*blockDiff.Old, *blockDiff.New) {
+			blockDiff.Change = BlockModified
+			oldText, newText := blockText(*blockDiff.Old), blockText(*blockDiff.New)
+			if oldText != newText {
+				blockDiff.Words = diffTokens(tokenizeText(oldText, false), tokenizeText(newText, false))
+			}
+		}
+		diff.Blocks = append(diff.Blocks, blockDiff)
+	}
+	addRemoved(len(oldDoc.Blocks))
+
+	return diff
+}
+
+// alignBlocks returns the index of the old block matching each new block, or -1 if there is none
+func alignBlocks(oldBlocks, newBlocks []EditorJSBlock, similarity float64) []int {
+	matches := make([]int, len(newBlocks))
+	matched := make([]bool, len(oldBlocks))
+	ids := map[string]int{}
+	for oi, block := range oldBlocks {
+		if _, ok := ids[block.ID]; ok {
+			// duplicated ids don't identify a block
+			ids[block.ID] = -1
+		} else if block.ID != "" {
+			ids[block.ID] = oi
+		}
+	}
+	for ni, block := range newBlocks {
+		matches[ni] = -1
+		if oi, ok := ids[block.ID]; ok && oi >= 0 && !matched[oi] {
+			matches[ni], matched[oi] = oi, true
+		}
+	}
+
+	type candidate struct {
+		oi, ni     int
+		similarity float64
+	}
+	candidates := []candidate{}
+	oldWords, newWords := similarityWords(oldBlocks), similarityWords(newBlocks)
+	for ni, newBlock := range newBlocks {
+		if matches[ni] >= 0 {
+			continue
+		}
+		// the old blocks nearest to the position of the new block are compared first, up to maxSimilarityCandidates
+		compared := 0
+		for d := 0; compared < maxSimilarityCandidates && (ni-d >= 0 || ni+d < len(oldBlocks)); d++ {
+			positions := []int{ni - d, ni + d}
+			if d == 0 {
+				positions = positions[:1]
+			}
+			for _, oi := range positions {
+				if oi < 0 || oi >= len(oldBlocks) {
+					continue
+				}
+				oldBlock := oldBlocks[oi]
+				if matched[oi] || oldBlock.Type != newBlock.Type || (oldBlock.ID != "" && newBlock.ID != "") {
+					continue
+				}
+				compared++
+				if maxSimilarity(oldWords[oi], newWords[ni]) < similarity {
+					continue
+				}
+				if s := blockSimilarity(oldBlock, newBlock, oldWords[oi], newWords[ni]); s >= similarity {
+					candidates = append(candidates, candidate{oi: oi, ni: ni, similarity: s})
+				}
+			}
+		}
+	}
+	// the most similar blocks are matched first, blocks at the same position before those farther apart
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].similarity != candidates[j].similarity {
+			return candidates[i].similarity > candidates[j].similarity
+		}
+		return abs(candidates[i].oi-candidates[i].ni) < abs(candidates[j].oi-candidates[j].ni)
+	})
+	for _, c := range candidates {
+		if matches[c.ni] < 0 && !matched[c.oi] {
+			matches[c.ni], matched[c.oi] = c.oi, true
+		}
+	}
+	return matches
+}
+
+// movedBlocks returns which new blocks were moved. The blocks that keep their order form the longest increasing
+// subsequence of the indexes of their old blocks, the other matched blocks were moved.
+func movedBlocks(matches []int, count int) []bool {
+	moved := make([]bool, count)
+	// tails[k] is the new index of the smallest old index ending an increasing subsequence of length k+1
+	tails := []int{}
+	prev := make([]int, count)
+	for ni, oi := range matches {
+		if oi < 0 {
+			continue
+		}
+		moved[ni] = true
+		k := sort.Search(len(tails), func(k int) bool { return matches[tails[k]] >= oi })
+		prev[ni] = -1
+		if k > 0 {
+			prev[ni] = tails[k-1]
+		}
+		if k == len(tails) {
+			tails = append(tails, ni)
+		} else {
+			tails[k] = ni
+		}
+	}
+	if len(tails) > 0 {
+		for ni := tails[len(tails)-1]; ni >= 0; ni = prev[ni] {
+			moved[ni] = false
+		}
+	}
+	return moved
+}
+
+// maxSimilarityCandidates is the maximum number of old blocks a new block without matching id is compared to
+const maxSimilarityCandidates = 64
+
+// similarityWords returns the words of the text of each block, nil for the blocks without text
+func similarityWords(blocks []EditorJSBlock) [][]string {
+	result := make([][]string, len(blocks))
+	for i, block := range blocks {
+		if text := blockText(block); text != "" {
+			result[i] = words(tokenizeText(text, false))
+		}
+	}
+	return result
+}
+
+// maxSimilarity returns the upper bound of the similarity of text blocks with the words a and b, given by their
+// lengths. It is 1 for blocks without text.
+func maxSimilarity(a, b []string) float64 {
+	if (a == nil && b == nil) || len(a)+len(b) == 0 {
+		return 1
+	}
+	shorter := len(a)
+	if len(b) < shorter {
+		shorter = len(b)
+	}
+	return 2 * float64(shorter) / float64(len(a)+len(b))
+}
+
+// blockSimilarity returns the share of equal words of text blocks and the share of equal data fields of other blocks.
+// wordsA and wordsB are the words of the blocks returned by similarityWords.
+func blockSimilarity(a, b EditorJSBlock, wordsA, wordsB []string) float64 {
+	if wordsA != nil || wordsB != nil {
+		if len(wordsA)+len(wordsB) == 0 {
+			return 1
+		}
+		return 2 * float64(lcsLength(wordsA, wordsB)) / float64(len(wordsA)+len(wordsB))
+	}
+
+	var dataA, dataB map[string]interface{}
+	if json.Unmarshal(a.Data, &dataA) != nil || json.Unmarshal(b.Data, &dataB) != nil {
+		if equalJSON(a.Data, b.Data) {
+			return 1
+		}
+		return 0
+	}
+	keys := map[string]bool{}
+	for key := range dataA {
+		keys[key] = true
+	}
+	for key := range dataB {
+		keys[key] = true
+	}
+	if len(keys) == 0 {
+		return 1
+	}
+	equal := 0
+	for key := range keys {
+		if reflect.DeepEqual(dataA[key], dataB[key]) {
+			equal++
+		}
+	}
+	return float64(equal) / float64(len(keys))
+}
+
+// words returns the tokens that aren't whitespace
+func words(tokens []string) []string {
+	result := []string{}
+	for _, token := range tokens {
+		if strings.TrimSpace(token) != "" {
+			result = append(result, token)
+		}
+	}
+	return result
+}
+
+// equalBlocks reports whether the blocks have the same type, data and tunes, ignoring the formatting of their JSON
+func equalBlocks(a, b EditorJSBlock) bool {
+	if a.Type != b.Type || !equalJSON(a.Data, b.Data) || len(a.Tunes) != len(b.Tunes) {
+		return false
+	}
+	for name, tune := range a.Tunes {
+		if !equalJSON(tune, b.Tunes[name]) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalJSON(a, b json.RawMessage) bool {
+	var valueA, valueB interface{}
+	if json.Unmarshal(a, &valueA) != nil || json.Unmarshal(b, &valueB) != nil {
+		return string(a) == string(b)
+	}
+	return reflect.DeepEqual(valueA, valueB)
+}
+
+// tokenizeText splits text into the tokens the inline diffs are made of: words, single CJK characters, runs of
+// whitespace and single other characters. If inlineHTML is set, tags and character references are tokens of their
+// own.
+func tokenizeText(text string, inlineHTML bool) []string {
+	tokens := []string{}
+	for len(text) > 0 {
+		if inlineHTML && text[0] == '<' {
+			if end := strings.IndexByte(text, '>'); end > 0 {
+				tokens, text = append(tokens, text[:end+1]), text[end+1:]
+				continue
+			}
+		}
+		if inlineHTML && text[0] == '&' {
+			if loc := entityRegexp.FindStringIndex(text); loc != nil {
+				tokens, text = append(tokens, text[:loc[1]]), text[loc[1]:]
+				continue
+			}
+		}
+
+		r, size := utf8.DecodeRuneInString(text)
+		class := tokenClass(r)
+		if class != 0 {
+			for size < len(text) {
+				next, nextSize := utf8.DecodeRuneInString(text[size:])
+				if tokenClass(next) != class {
+					break
+				}
+				size += nextSize
+			}
+		}
+		tokens, text = append(tokens, text[:size]), text[size:]
+	}
+	return tokens
+}
+
+// tokenClass returns 1 for the runes of words, 2 for whitespace and 0 for the runes that are tokens of their own
+func tokenClass(r rune) int {
+	switch {
+	case isCJK(r):
+		return 0
+	case unicode.IsLetter(r), unicode.IsDigit(r), r == '_':
+		return 1
+	case unicode.IsSpace(r):
+		return 2
+	}
+	return 0
+}
+
+// lcsLength returns the length of the longest common subsequence of a and b
+func lcsLength(a, b []string) int {
+	return lcsRow(a, b)[0]
+}
+
+// lcsRow returns the lengths of the longest common subsequences of a and every suffix of b: row[j] is the length for
+// b[j:]. It takes linear space.
+func lcsRow(a, b []string) []int {
+	row := make([]int, len(b)+1)
+	for i := len(a) - 1; i >= 0; i-- {
+		diagonal := 0
+		for j := len(b) - 1; j >= 0; j-- {
+			current := row[j]
+			if a[i] == b[j] {
+				row[j] = diagonal + 1
+			} else if row[j+1] > row[j] {
+				row[j] = row[j+1]
+			}
+			diagonal = current
+		}
+	}
+	return row
+}
+
+// reversed returns a reversed copy of tokens
+func reversed(tokens []string) []string {
+	result := make([]string, len(tokens))
+	for i, token := range tokens {
+		result[len(tokens)-1-i] = token
+	}
+	return result
+}
+
+// diffTokens returns the diff of the token lists, merging adjacent tokens with the same operation
+func diffTokens(a, b []string) []WordDiff {
+	merged := []WordDiff{}
+	for _, d := range diffTokenOps(a, b) {
+		if n := len(merged); n > 0 && merged[n-1].Op == d.Op {
+			merged[n-1].Text += d.Text
+		} else {
+			merged = append(merged, d)
+		}
+	}
+	return merged
+}
+
+// diffTokenOps returns the diff of the token lists with one operation per token. Within a run of changes, deletions
+// precede insertions.
+func diffTokenOps(a, b []string) []WordDiff {
+	// the common prefix and suffix are equal, only the tokens between them are aligned
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	ops := make([]DiffOp, 0, len(a)+len(b))
+	for k := 0; k < prefix; k++ {
+		ops = append(ops, DiffEqual)
+	}
+	ops = alignTokens(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix], ops)
+	for k := 0; k < suffix; k++ {
+		ops = append(ops, DiffEqual)
+	}
+
+	result := []WordDiff{}
+	deleted, inserted := []WordDiff{}, []WordDiff{}
+	flush := func() {
+		result = append(append(result, deleted...), inserted...)
+		deleted, inserted = deleted[:0], inserted[:0]
+	}
+	i, j := 0, 0
+	for _, op := range ops {
+		switch op {
+		case DiffEqual:
+			flush()
+			result = append(result, WordDiff{Op: DiffEqual, Text: a[i]})
+			i, j = i+1, j+1
+		case DiffDelete:
+			deleted = append(deleted, WordDiff{Op: DiffDelete, Text: a[i]})
+			i++
+		default:
+			inserted = append(inserted, WordDiff{Op: DiffInsert, Text: b[j]})
+			j++
+		}
+	}
+	flush()
+	return result
+}
+
+// alignTokens appends the operations turning a into b to ops, one per token. It splits a in half and b where the
+// longest common subsequences of the halves add up to the longest one of a and b, and aligns the halves recursively,
+// which is Hirschberg's algorithm and takes linear space.
+func alignTokens(a, b []string, ops []DiffOp) []DiffOp {
+	switch {
+	case len(a) == 0:
+		for range b {
+			ops = append(ops, DiffInsert)
+		}
+		return ops
+	case len(b) == 0:
+		for range a {
+			ops = append(ops, DiffDelete)
+		}
+		return ops
+	case len(a) == 1:
+		for j, token := range b {
+			if token == a[0] {
+				for k := 0; k < j; k++ {
+					ops = append(ops, DiffInsert)
+				}
+				ops = append(ops, DiffEqual)
+				for k := j + 1; k < len(b); k++ {
+					ops = append(ops, DiffInsert)
+				}
+				return ops
+			}
+		}
+		ops = append(ops, DiffDelete)
+		for range b {
+			ops = append(ops, DiffInsert)
+		}
+		return ops
+	}
+
+	middle := len(a) / 2
+	// forward[len(b)-k] is the length for a[:middle] and b[:k], backward[k] the one for a[middle:] and b[k:]
+	forward := lcsRow(reversed(a[:middle]), reversed(b))
+	backward := lcsRow(a[middle:], b)
+	split, best := 0, -1
+	for k := 0; k <= len(b); k++ {
+		if length := forward[len(b)-k] + backward[k]; length > best {
+			split, best = k, length
+		}
+	}
+	ops = alignTokens(a[:middle], b[:split], ops)
+	return alignTokens(a[middle:], b[split:], ops)
+}
+
+// diffInlineHTML returns the new inline html with the deleted text marked by <del> and the inserted text by <ins>.
+// The tags of the result are the tags of the new html, so the markup stays well-formed.
+func diffInlineHTML(oldHTML, newHTML string) string {
+	sb := strings.Builder{}
+	open := ""
+	mark := func(tag string) {
+		if open == tag {
+			return
+		}
+		if open != "" {
+			sb.WriteString("</" + open + ">")
+		}
+		if tag != "" {
+			sb.WriteString("<" + tag + ">")
+		}
+		open = tag
+	}
+
+	for _, d := range diffTokenOps(tokenizeText(oldHTML, true), tokenizeText(newHTML, true)) {
+		isTag := len(d.Text) > 1 && d.Text[0] == '<' && d.Text[len(d.Text)-1] == '>'
+		switch {
+		case isTag && d.Op == DiffDelete:
+			continue
+		case isTag, d.Op == DiffEqual:
+			mark("")
+		case d.Op == DiffDelete:
+			mark("del")
+		default:
+			mark("ins")
+		}
+		sb.WriteString(d.Text)
+	}
+	mark("")
+	return sb.String()
+}
+
+// diffTextFields are the data fields holding the inline html of the text blocks, which are diffed inline
+var diffTextFields = map[string][]string{
+	"header":    {"text"},
+	"paragraph": {"text"},
+	"list":      {"items"},
+	"quote":     {"text", "caption"},
+	"warning":   {"title", "message"},
+	"alert":     {"message"},
+	"checklist": {"items"},
+	"table":     {"content"},
+	"toggle":    {"text"},
+}
+
+// diffBlock returns the new block with the changes of its text fields marked by <del> and <ins>, or false if the
+// block has no text fields to diff
+func diffBlock(oldBlock, newBlock EditorJSBlock) (EditorJSBlock, bool) {
+	fields, ok := diffTextFields[newBlock.Type]
+	if !ok || oldBlock.Type != newBlock.Type {
+		return newBlock, false
+	}
+	var oldData, newData map[string]interface{}
+	if json.Unmarshal(oldBlock.Data, &oldData) != nil || json.Unmarshal(newBlock.Data, &newData) != nil {
+		return newBlock, false
+	}
+	for _, field := range fields {
+		if value, ok := newData[field]; ok {
+			newData[field] = diffValue(oldData[field], value)
+		}
+	}
+	data, err := json.Marshal(newData)
+	if err != nil {
+		return newBlock, false
+	}
+	newBlock.Data = data
+	return newBlock, true
+}
+
+// diffValue returns the new value with the changes of its strings marked. Array elements are aligned by equality,
+// changed elements between aligned ones are diffed pairwise.
+func diffValue(oldValue, newValue interface{}) interface{} {
+	switch newV := newValue.(type) {
+	case string:
+		if oldV, ok := oldValue.(string); ok {
+			return diffInlineHTML(oldV, newV)
+		}
+		return markStrings(newV, "ins")
+	case map[string]interface{}:
+		oldV, _ := oldValue.(map[string]interface{})
+		result := map[string]interface{}{}
+		for key, value := range newV {
+			if old, ok := oldV[key]; ok {
+				result[key] = diffValue(old, value)
+			} else {
+				result[key] = markStrings(value, "ins")
+			}
+		}
+		return result
+	case []interface{}:
+		oldV, _ := oldValue.([]interface{})
+		return diffArray(oldV, newV)
+	}
+	return newValue
+}
+
+func diffArray(oldValues, newValues []interface{}) []interface{} {
+	oldKeys, newKeys := make([]string, len(oldValues)), make([]string, len(newValues))
+	for i, v := range oldValues {
+		oldKeys[i] = fmt.Sprintf("%#v", v)
+	}
+	for i, v := range newValues {
+		newKeys[i] = fmt.Sprintf("%#v", v)
+	}
+
+	result := []interface{}{}
+	oi, ni := 0, 0
+	deleted, inserted := []interface{}{}, []interface{}{}
+	flush := func() {
+		for k := 0; k < len(deleted) || k < len(inserted); k++ {
+			switch {
+			case k < len(deleted) && k < len(inserted):
+				result = append(result, diffValue(deleted[k], inserted[k]))
+			case k < len(inserted):
+				result = append(result, markStrings(inserted[k], "ins"))
+			default:
+				result = append(result, markStrings(deleted[k], "del"))
+			}
+		}
+		deleted, inserted = deleted[:0], inserted[:0]
+	}
+	for _, d := range diffTokenOps(oldKeys, newKeys) {
+		switch d.Op {
+		case DiffEqual:
+			flush()
+			result = append(result, newValues[ni])
+			oi, ni = oi+1, ni+1
+		case DiffDelete:
+			deleted = append(deleted, oldValues[oi])
+			oi++
+		default:
+			inserted = append(inserted, newValues[ni])
+			ni++
+		}
+	}
+	flush()
+	return result
+}
+
+// markStrings wraps the strings of value into tag
+func markStrings(value interface{}, tag string) interface{} {
+	switch v := value.(type) {
+	case string:
+		if v == "" {
+			return v
+		}
+		return "<" + tag + ">" + v + "</" + tag + ">"
+	case map[string]interface{}:
+		result := map[string]interface{}{}
+		for key, value := range v {
+			result[key] = markStrings(value, tag)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, value := range v {
+			result[i] = markStrings(value, tag)
+		}
+		return result
+	}
+	return value
+}
+
+// GenerateDiffHTML generates the html of the new version of a diff using the engine's handlers. Added blocks are
+// wrapped in <ins>, removed blocks in <del>. The text changes of modified text blocks are marked inline, other
+// modified blocks show the old block in <del> followed by the new one in <ins>. Modified and moved blocks are
+// wrapped in a <div>. The elements carry the classes "diff" and "diff--added", "diff--removed", "diff--modified" or
+// "diff--moved". The blocks owned by a toggle are rendered inside it, following the toggle of the new version, or of
+// the old version for removed blocks.
+func (htmlEngine *HTMLEngine) GenerateDiffHTML(diff *DocumentDiff) (string, error) {
+	engine := htmlEngine.document()
+	top, children := diffOwners(diff)
+	html, err := engine.generateBlockDiffs(diff, top, children)
+	if err != nil {
+		return "", err
+	}
+	return engine.wrap(diff.New, html+engine.footnotes.html(engine))
+}
+
+// diffOwners returns the indexes of the block diffs at the top level and the indexes of the block diffs owned by each
+// block diff, in the order of the diff. Blocks are owned by the diff of the toggle owning them in the new version, or
+// in the old version for removed blocks.
+func diffOwners(diff *DocumentDiff) ([]int, map[int][]int) {
+	newOwners, _ := blockOwners(diff.New.Blocks)
+	oldOwners, _ := blockOwners(diff.Old.Blocks)
+	byNew, byOld := map[int]int{}, map[int]int{}
+	for i, blockDiff := range diff.Blocks {
+		if blockDiff.NewIndex >= 0 {
+			byNew[blockDiff.NewIndex] = i
+		}
+		if blockDiff.OldIndex >= 0 {
+			byOld[blockDiff.OldIndex] = i
+		}
+	}
+
+	top, children := []int{}, map[int][]int{}
+	for i, blockDiff := range diff.Blocks {
+		owner := -1
+		if blockDiff.NewIndex >= 0 {
+			if o := newOwners[blockDiff.NewIndex]; o >= 0 {
+				owner = byNew[o]
+			}
+		} else if o := oldOwners[blockDiff.OldIndex]; o >= 0 {
+			owner = byOld[o]
+		}
+		if owner < 0 {
+			top = append(top, i)
+		} else {
+			children[owner] = append(children[owner], i)
+		}
+	}
+	return top, children
+}
+
+// generateBlockDiffs generates the html of the block diffs at indexes, with the block diffs they own as children
+func (htmlEngine *HTMLEngine) generateBlockDiffs(diff *DocumentDiff, indexes []int, children map[int][]int) (string, error) {
+	sb := strings.Builder{}
+	for _, i := range indexes {
+		blockDiff := diff.Blocks[i]
+		// the footnotes of an owner are numbered before the ones of its children
+		if htmlEngine.footnotes != nil {
+			for _, block := range []*EditorJSBlock{blockDiff.Old, blockDiff.New} {
+				if block != nil {
+					htmlEngine.footnotes.reserve(*block)
+				}
+			}
+		}
+		childrenHTML, err := htmlEngine.generateBlockDiffs(diff, children[i], children)
+		if err != nil {
+			return "", err
+		}
+		html, err := htmlEngine.generateBlockDiff(blockDiff, childrenHTML)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(html)
+	}
+	return sb.String(), nil
+}
+
+// generateDiffBlock generates the html of a block of a diff. Owners render children, the html of the diffs of the
+// blocks they own, inside them, other blocks are followed by it.
+func (htmlEngine *HTMLEngine) generateDiffBlock(block EditorJSBlock, children string) (string, error) {
+	handler, ok := htmlEngine.BlockHandlers[block.Type].(HTMLBlockOwnerHandler)
+	if !ok {
+		html, err := htmlEngine.GenerateBlocksHTML([]EditorJSBlock{block})
+		return html + children, err
+	}
+	return htmlEngine.renderBlock(handler, block, children)
+}
+
+func (htmlEngine *HTMLEngine) generateBlockDiff(blockDiff BlockDiff, children string) (string, error) {
+	switch blockDiff.Change {
+	case BlockAdded:
+		html, err := htmlEngine.generateDiffBlock(*blockDiff.New, children)
+		return fmt.Sprintf("<ins%s>%s</ins>", classAttr(nil, htmlEngine, "diff--added", "diff", "diff--added"), html), err
+	case BlockRemoved:
+		html, err := htmlEngine.generateDiffBlock(*blockDiff.Old, children)
+		return fmt.Sprintf("<del%s>%s</del>", classAttr(nil, htmlEngine, "diff--removed", "diff", "diff--removed"), html), err
+	}
+
+	html := ""
+	if blockDiff.Change == BlockModified {
+		if block, ok := diffBlock(*blockDiff.Old, *blockDiff.New); ok {
+			var err error
+			if html, err = htmlEngine.generateDiffBlock(block, children); err != nil {
+				return "", err
+			}
+		} else {
+			// the children are shown once, in the new version
+			oldHTML, err := htmlEngine.generateDiffBlock(*blockDiff.Old, "")
+			if err != nil {
+				return "", err
+			}
+			newHTML, err := htmlEngine.generateDiffBlock(*blockDiff.New, children)
+			if err != nil {
+				return "", err
+			}
+			html = fmt.Sprintf("<del>%s</del><ins>%s</ins>", oldHTML, newHTML)
+		}
+	} else {
+		var err error
+		if html, err = htmlEngine.generateDiffBlock(*blockDiff.New, children); err != nil {
+			return "", err
+		}
+	}
+
+	switch {
+	case blockDiff.Change == BlockModified && blockDiff.Moved:
+		return fmt.Sprintf("<div%s>%s</div>", classAttr(nil, htmlEngine, "diff--modified", "diff", "diff--modified", "diff--moved"), html), nil
+	case blockDiff.Change == BlockModified:
+		return fmt.Sprintf("<div%s>%s</div>", classAttr(nil, htmlEngine, "diff--modified", "diff", "diff--modified"), html), nil
+	case blockDiff.Moved:
+		return fmt.Sprintf("<div%s>%s</div>", classAttr(nil, htmlEngine, "diff--moved", "diff", "diff--moved"), html), nil
+	}
+	return html, nil
+}
+
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
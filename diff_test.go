@@ -0,0 +1,217 @@
+package goeditorjs_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/davidscottmills/goeditorjs"
+	"github.com/stretchr/testify/require"
+)
+
+func parseDocument(t *testing.T, data string) *goeditorjs.Document {
+	doc, err := goeditorjs.ParseDocument(data)
+	require.NoError(t, err)
+	return doc
+}
+
+func Test_Diff_IDs(t *testing.T) {
+	oldDoc := parseDocument(t, `{"blocks": [
+		{"id": "a", "type": "header", "data": {"text": "Title", "level": 1}},
+		{"id": "b", "type": "paragraph", "data": {"text": "The quick brown fox", "alignment": "left"}},
+		{"id": "c", "type": "delimiter", "data": {}},
+		{"id": "d", "type": "paragraph", "data": {"text": "Gone", "alignment": "left"}},
+		{"id": "e", "type": "paragraph", "data": {"text": "Last", "alignment": "left"}}
+	]}`)
+	newDoc := parseDocument(t, `{"blocks": [
+		{"id": "e", "type": "paragraph", "data": {"alignment": "left", "text": "Last"}},
+		{"id": "a", "type": "header", "data": {"text": "Title", "level": 1}},
+		{"id": "b", "type": "paragraph", "data": {"text": "The slow brown fox jumps", "alignment": "left"}},
+		{"id": "x", "type": "paragraph", "data": {"text": "New", "alignment": "left"}},
+		{"id": "c", "type": "delimiter", "data": {}}
+	]}`)
+
+	diff := goeditorjs.Diff(oldDoc, newDoc, nil)
+	type change struct {
+		change   goeditorjs.BlockChange
+		moved    bool
+		oldIndex int
+		newIndex int
+	}
+	changes := []change{}
+	for _, b := range diff.Blocks {
+		changes = append(changes, change{b.Change, b.Moved, b.OldIndex, b.NewIndex})
+	}
+	require.Equal(t, []change{
+		{goeditorjs.BlockUnchanged, true, 4, 0},
+		{goeditorjs.BlockUnchanged, false, 0, 1},
+		{goeditorjs.BlockModified, false, 1, 2},
+		{goeditorjs.BlockAdded, false, -1, 3},
+		{goeditorjs.BlockUnchanged, false, 2, 4},
+		{goeditorjs.BlockRemoved, false, 3, -1},
+	}, changes)
+
+	require.Equal(t, []goeditorjs.WordDiff{
+		{Op: goeditorjs.DiffEqual, Text: "The "},
+		{Op: goeditorjs.DiffDelete, Text: "quick"},
+		{Op: goeditorjs.DiffInsert, Text: "slow"},
+		{Op: goeditorjs.DiffEqual, Text: " brown fox"},
+		{Op: goeditorjs.DiffInsert, Text: " jumps"},
+	}, diff.Blocks[2].Words)
+	require.Equal(t, "b", diff.Blocks[2].New.ID)
+}
+
+func Test_Diff_Similarity(t *testing.T) {
+	oldDoc := parseDocument(t, `{"blocks": [
+		{"type": "paragraph", "data": {"text": "Editor.js 是一个块编辑器", "alignment": "left"}},
+		{"type": "image", "data": {"file": {"url": "a.png"}, "caption": "A", "withBorder": false}},
+		{"type": "paragraph", "data": {"text": "Something else entirely", "alignment": "left"}}
+	]}`)
+	newDoc := parseDocument(t, `{"blocks": [
+		{"type": "paragraph", "data": {"text": "Completely different words", "alignment": "left"}},
+		{"type": "paragraph", "data": {"text": "Editor.js 是一个好的块编辑器", "alignment": "left"}},
+		{"type": "image", "data": {"file": {"url": "a.png"}, "caption": "B", "withBorder": false}}
+	]}`)
+
+	diff := goeditorjs.Diff(oldDoc, newDoc, nil)
+	changes := []goeditorjs.BlockChange{}
+	for _, b := range diff.Blocks {
+		changes = append(changes, b.Change)
+	}
+	require.Equal(t, []goeditorjs.BlockChange{goeditorjs.BlockAdded, goeditorjs.BlockModified,
+		goeditorjs.BlockModified, goeditorjs.BlockRemoved}, changes)
+	require.Equal(t, []goeditorjs.WordDiff{
+		{Op: goeditorjs.DiffEqual, Text: "Editor.js 是一个"},
+		{Op: goeditorjs.DiffInsert, Text: "好的"},
+		{Op: goeditorjs.DiffEqual, Text: "块编辑器"},
+	}, diff.Blocks[1].Words)
+	require.Nil(t, diff.Blocks[2].Words)
+
+	// blocks with different ids are never matched by similarity
+	oldDoc.Blocks[0].ID, newDoc.Blocks[1].ID = "a", "b"
+	diff = goeditorjs.Diff(oldDoc, newDoc, &goeditorjs.DiffOptions{Similarity: 0.9})
+	changes = []goeditorjs.BlockChange{}
+	for _, b := range diff.Blocks {
+		changes = append(changes, b.Change)
+	}
+	require.Equal(t, []goeditorjs.BlockChange{goeditorjs.BlockAdded, goeditorjs.BlockAdded, goeditorjs.BlockAdded,
+		goeditorjs.BlockRemoved, goeditorjs.BlockRemoved, goeditorjs.BlockRemoved}, changes)
+}
+
+func Test_Diff_Large(t *testing.T) {
+	paragraphs := func(texts ...string) *goeditorjs.Document {
+		doc := &goeditorjs.Document{}
+		for _, text := range texts {
+			data, err := json.Marshal(map[string]string{"text": text, "alignment": "left"})
+			require.NoError(t, err)
+			doc.Blocks = append(doc.Blocks, goeditorjs.EditorJSBlock{Type: "paragraph", Data: data})
+		}
+		return doc
+	}
+
+	oldWords, newWords := []string{}, []string{}
+	for i := 0; i < 3000; i++ {
+		oldWords = append(oldWords, fmt.Sprintf("w%d", i))
+		if i%100 == 50 {
+			newWords = append(newWords, fmt.Sprintf("x%d", i))
+		} else {
+			newWords = append(newWords, fmt.Sprintf("w%d", i))
+		}
+	}
+	diff := goeditorjs.Diff(paragraphs(strings.Join(oldWords, " ")), paragraphs(strings.Join(newWords, " ")), nil)
+	require.Len(t, diff.Blocks, 1)
+	oldText, newText, changes := "", "", 0
+	for _, w := range diff.Blocks[0].Words {
+		if w.Op != goeditorjs.DiffInsert {
+			oldText += w.Text
+		}
+		if w.Op != goeditorjs.DiffDelete {
+			newText += w.Text
+		}
+		if w.Op == goeditorjs.DiffInsert {
+			changes++
+		}
+	}
+	require.Equal(t, strings.Join(oldWords, " "), oldText)
+	require.Equal(t, strings.Join(newWords, " "), newText)
+	require.Equal(t, 30, changes)
+
+	// many blocks without ids
+	oldTexts, newTexts := []string{}, []string{}
+	for i := 0; i < 2000; i++ {
+		oldTexts = append(oldTexts, fmt.Sprintf("paragraph %d of the document", i))
+		newTexts = append(newTexts, fmt.Sprintf("paragraph %d of the new document", i))
+	}
+	diff = goeditorjs.Diff(paragraphs(oldTexts...), paragraphs(newTexts...), nil)
+	require.Len(t, diff.Blocks, 2000)
+	for _, b := range diff.Blocks {
+		require.Equal(t, goeditorjs.BlockModified, b.Change)
+		require.Equal(t, b.OldIndex, b.NewIndex)
+	}
+}
+
+func Test_HTMLEngine_GenerateDiffHTML(t *testing.T) {
+	oldDoc := parseDocument(t, `{"blocks": [
+		{"id": "a", "type": "paragraph", "data": {"text": "Hello <b>big</b> world", "alignment": "left"}},
+		{"id": "b", "type": "list", "data": {"style": "unordered", "items": ["one", "two", "three"]}},
+		{"id": "c", "type": "code", "data": {"code": "old()"}},
+		{"id": "d", "type": "delimiter", "data": {}}
+	]}`)
+	newDoc := parseDocument(t, `{"blocks": [
+		{"id": "d", "type": "delimiter", "data": {}},
+		{"id": "a", "type": "paragraph", "data": {"text": "Hello <i>small</i> world &amp; more", "alignment": "left"}},
+		{"id": "b", "type": "list", "data": {"style": "unordered", "items": ["one", "2", "three", "four"]}},
+		{"id": "c", "type": "code", "data": {"code": "new()"}},
+		{"id": "e", "type": "header", "data": {"text": "New", "level": 2}}
+	]}`)
+
+	eng := goeditorjs.NewHTMLEngine()
+	eng.RegisterBlockHandlers(&goeditorjs.ParagraphHandler{}, &goeditorjs.ListHandler{}, &goeditorjs.CodeHandler{},
+		&goeditorjs.DelimiterHandler{}, &goeditorjs.HeaderHandler{})
+	result, err := eng.GenerateDiffHTML(goeditorjs.Diff(oldDoc, newDoc, nil))
+	require.NoError(t, err)
+	require.Equal(t, `<div class="diff diff--moved"><hr/></div>`+
+		`<div class="diff diff--modified"><p>Hello <del>big</del><i><ins>small</ins></i> world<ins> &amp; more</ins></p></div>`+
+		`<div class="diff diff--modified"><ul><li>one</li><li><del>two</del><ins>2</ins></li><li>three</li><li><ins>four</ins></li></ul></div>`+
+		`<div class="diff diff--modified"><del><pre><code>old()</code></pre></del><ins><pre><code>new()</code></pre></ins></div>`+
+		`<ins class="diff diff--added"><h2>New</h2></ins>`, result)
+
+	eng = goeditorjs.NewHTMLEngine(goeditorjs.WithClassMap(goeditorjs.ClassMap{"diff--added": "bg-green"}))
+	eng.RegisterBlockHandlers(&goeditorjs.ParagraphHandler{})
+	result, err = eng.GenerateDiffHTML(goeditorjs.Diff(&goeditorjs.Document{}, parseDocument(t, `{"blocks": [
+		{"type": "paragraph", "data": {"text": "x", "alignment": "left"}}]}`), nil))
+	require.NoError(t, err)
+	require.Equal(t, `<ins class="diff diff--added bg-green"><p>x</p></ins>`, result)
+
+	_, err = eng.GenerateDiffHTML(goeditorjs.Diff(oldDoc, &goeditorjs.Document{}, nil))
+	require.Error(t, err)
+}
+
+func Test_HTMLEngine_GenerateDiffHTML_Toggle(t *testing.T) {
+	oldDoc := parseDocument(t, `{"blocks": [
+		{"id": "t", "type": "toggle", "data": {"text": "More", "status": "open", "items": 2}},
+		{"id": "a", "type": "paragraph", "data": {"text": "one", "alignment": "left"}},
+		{"id": "b", "type": "paragraph", "data": {"text": "two", "alignment": "left"}},
+		{"id": "c", "type": "paragraph", "data": {"text": "after", "alignment": "left"}}
+	]}`)
+	newDoc := parseDocument(t, `{"blocks": [
+		{"id": "t", "type": "toggle", "data": {"text": "More", "status": "open", "items": 2}},
+		{"id": "a", "type": "paragraph", "data": {"text": "first", "alignment": "left"}},
+		{"id": "d", "type": "paragraph", "data": {"text": "three", "alignment": "left"}},
+		{"id": "c", "type": "paragraph", "data": {"text": "after", "alignment": "left"}},
+		{"id": "u", "type": "toggle", "data": {"text": "New", "status": "closed", "items": 1}},
+		{"id": "e", "type": "paragraph", "data": {"text": "inside", "alignment": "left"}}
+	]}`)
+
+	eng := goeditorjs.NewHTMLEngine()
+	eng.RegisterBlockHandlers(&goeditorjs.ToggleHandler{}, &goeditorjs.ParagraphHandler{})
+	result, err := eng.GenerateDiffHTML(goeditorjs.Diff(oldDoc, newDoc, nil))
+	require.NoError(t, err)
+	require.Equal(t, `<details open><summary>More</summary>`+
+		`<div class="diff diff--modified"><p><del>one</del><ins>first</ins></p></div>`+
+		`<ins class="diff diff--added"><p>three</p></ins>`+
+		`<del class="diff diff--removed"><p>two</p></del></details>`+
+		`<p>after</p>`+
+		`<ins class="diff diff--added"><details><summary>New</summary><ins class="diff diff--added"><p>inside</p></ins></details></ins>`, result)
+}
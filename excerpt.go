@@ -0,0 +1,143 @@
+package goeditorjs
+
+import (
+	"encoding/json"
+	"strings"
+	"unicode"
+)
+
+// ExcerptBoundary is where Document.Excerpt truncates the text
+type ExcerptBoundary int
+
+const (
+	// ExcerptWordBoundary truncates after the last whole word and appends the ellipsis
+	ExcerptWordBoundary ExcerptBoundary = iota
+	// ExcerptSentenceBoundary truncates after the last whole sentence. Text without a whole sentence within the
+	// maximum length is truncated at a word boundary.
+	ExcerptSentenceBoundary
+)
+
+// ExcerptOptions are the options available to Document.Excerpt
+type ExcerptOptions struct {
+	// MaxLength is the maximum length of the text in runes, including the ellipsis. The text isn't truncated if it
+	// is 0.
+	MaxLength int
+	// Boundary is where the text is truncated
+	Boundary ExcerptBoundary
+	// Ellipsis is appended to text truncated at a word boundary
+	Ellipsis string
+	// SkipLeadingHeader leaves the header out of the text if the document starts with one, e.g. because the card
+	// shows the title separately
+	SkipLeadingHeader bool
+	// ImageTypes are the types of the ImageHandler shaped blocks the image is taken from.
+	// If nil, "image" and "simpleImage" are used.
+	ImageTypes []string
+}
+
+// DefaultExcerptOptions are the default options available to Document.Excerpt
+var DefaultExcerptOptions = &ExcerptOptions{MaxLength: 200, Boundary: ExcerptWordBoundary, Ellipsis: "…"}
+
+// Excerpt is a plain text preview of a document
+type Excerpt struct {
+	// Text is the plain text of the paragraph, header, list and quote blocks
+	Text string
+	// Truncated is set if Text was truncated
+	Truncated bool
+	// Image is the url of the first image, or empty if the document has none
+	Image string
+}
+
+// excerptBlockTypes are the types of the blocks whose text makes up the excerpt
+var excerptBlockTypes = map[string]bool{"paragraph": true, "header": true, "list": true, "quote": true}
+
+// Excerpt returns a plain text preview of the document. If options is nil, DefaultExcerptOptions will be used.
+func (d *Document) Excerpt(options *ExcerptOptions) *Excerpt {
+	if options == nil {
+		options = DefaultExcerptOptions
+	}
+	imageTypes := options.ImageTypes
+	if imageTypes == nil {
+		imageTypes = []string{"image", "simpleImage"}
+	}
+
+	excerpt := &Excerpt{}
+	texts := []string{}
+	for i, block := range d.Blocks {
+		if excerpt.Image == "" && containsString(imageTypes, block.Type) {
+			image := &Image{}
+			if json.Unmarshal(block.Data, image) == nil {
+				excerpt.Image = image.File.URL
+				if excerpt.Image == "" {
+					excerpt.Image = image.URL
+				}
+			}
+		}
+		if !excerptBlockTypes[block.Type] || (i == 0 && block.Type == "header" && options.SkipLeadingHeader) {
+			continue
+		}
+		if text := blockText(block); text != "" {
+			texts = append(texts, text)
+		}
+	}
+
+	excerpt.Text = strings.Join(strings.Fields(strings.Join(texts, " ")), " ")
+	excerpt.Text, excerpt.Truncated = truncateText(excerpt.Text, options)
+	return excerpt
+}
+
+// truncateText truncates text to the maximum length of options at the configured boundary
+func truncateText(text string, options *ExcerptOptions) (string, bool) {
+	runes := []rune(text)
+	if options.MaxLength <= 0 || len(runes) <= options.MaxLength {
+		return text, false
+	}
+
+	if options.Boundary == ExcerptSentenceBoundary {
+		for i := options.MaxLength - 1; i > 0; i-- {
+			// a sentence ends with its punctuation, followed by a space unless it is CJK punctuation
+			if isSentenceEnd(runes[i]) && (runes[i] > unicode.MaxASCII || unicode.IsSpace(runes[i+1])) {
+				return string(runes[:i+1]), true
+			}
+		}
+	}
+
+	limit := options.MaxLength - len([]rune(options.Ellipsis))
+	if limit < 0 {
+		limit = 0
+	}
+	cut := limit
+	// cut before the word the limit falls into, unless it is the only one. CJK text can be cut between any runes.
+	if !isWordBoundary(runes, limit) {
+		for cut > 0 && !isWordBoundary(runes, cut) {
+			cut--
+		}
+		if cut == 0 {
+			cut = limit
+		}
+	}
+	truncated := strings.TrimRightFunc(string(runes[:cut]), func(r rune) bool {
+		return unicode.IsSpace(r) || strings.ContainsRune(",;:-–—", r)
+	})
+	return truncated + options.Ellipsis, true
+}
+
+// isWordBoundary reports whether text can be cut before the rune at i without splitting a word
+func isWordBoundary(runes []rune, i int) bool {
+	if i == 0 {
+		return true
+	}
+	return unicode.IsSpace(runes[i]) || unicode.IsSpace(runes[i-1]) || isCJK(runes[i]) || isCJK(runes[i-1])
+}
+
+func isSentenceEnd(r rune) bool {
+	return strings.ContainsRune(".!?。！？", r)
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+package goeditorjs_test
+
+import (
+	"testing"
+
+	"github.com/davidscottmills/goeditorjs"
+	"github.com/stretchr/testify/require"
+)
+
+const excerptData = `{"blocks": [
+	{"type": "header", "data": {"text": "Title", "level": 1}},
+	{"type": "code", "data": {"code": "ignored()"}},
+	{"type": "paragraph", "data": {"text": "The <b>first</b> sentence. And&nbsp;a second one, which is longer!", "alignment": "left"}},
+	{"type": "image", "data": {"file": {"url": "https://example.com/a.png"}, "caption": "ignored"}},
+	{"type": "list", "data": {"style": "unordered", "items": ["one", "two<br>three"]}},
+	{"type": "simpleImage", "data": {"url": "https://example.com/b.png"}}
+]}`
+
+func Test_ParseDocument(t *testing.T) {
+	doc, err := goeditorjs.ParseDocument(`{"time": 1, "version": "2.28.2", "blocks": [{"type": "paragraph", "data": {}}]}`)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), doc.Time)
+	require.Equal(t, "2.28.2", doc.Version)
+	require.Len(t, doc.Blocks, 1)
+
+	_, err = goeditorjs.ParseDocument(`{`)
+	require.Error(t, err)
+}
+
+func Test_Document_Excerpt(t *testing.T) {
+	doc, err := goeditorjs.ParseDocument(excerptData)
+	require.NoError(t, err)
+
+	excerpt := doc.Excerpt(nil)
+	require.Equal(t, &goeditorjs.Excerpt{
+		Text:  "Title The first sentence. And a second one, which is longer! one two three",
+		Image: "https://example.com/a.png",
+	}, excerpt)
+
+	testData := []struct {
+		options  *goeditorjs.ExcerptOptions
+		expected string
+	}{
+		{options: &goeditorjs.ExcerptOptions{MaxLength: 30, Ellipsis: "…", SkipLeadingHeader: true},
+			expected: "The first sentence. And a…"},
+		{options: &goeditorjs.ExcerptOptions{MaxLength: 43, Ellipsis: "...", SkipLeadingHeader: true},
+			expected: "The first sentence. And a second one..."},
+		{options: &goeditorjs.ExcerptOptions{MaxLength: 40, Boundary: goeditorjs.ExcerptSentenceBoundary, SkipLeadingHeader: true},
+			expected: "The first sentence."},
+		{options: &goeditorjs.ExcerptOptions{MaxLength: 12, Boundary: goeditorjs.ExcerptSentenceBoundary, Ellipsis: "…"},
+			expected: "Title The…"},
+		{options: &goeditorjs.ExcerptOptions{MaxLength: 4, Ellipsis: "…"},
+			expected: "Tit…"},
+	}
+	for _, td := range testData {
+		excerpt := doc.Excerpt(td.options)
+		require.Equal(t, td.expected, excerpt.Text)
+		require.True(t, excerpt.Truncated)
+		require.LessOrEqual(t, len([]rune(excerpt.Text)), td.options.MaxLength)
+	}
+}
+
+func Test_Document_Excerpt_CJK(t *testing.T) {
+	doc, err := goeditorjs.ParseDocument(`{"blocks": [{"type": "paragraph", "data": {"text": "这是第一句。这是第二句。"}}]}`)
+	require.NoError(t, err)
+
+	require.Equal(t, "这是第一句。", doc.Excerpt(&goeditorjs.ExcerptOptions{MaxLength: 8, Boundary: goeditorjs.ExcerptSentenceBoundary}).Text)
+	require.Equal(t, "这是第一…", doc.Excerpt(&goeditorjs.ExcerptOptions{MaxLength: 5, Ellipsis: "…"}).Text)
+
+	excerpt := doc.Excerpt(&goeditorjs.ExcerptOptions{ImageTypes: []string{}})
+	require.Equal(t, "这是第一句。这是第二句。", excerpt.Text)
+	require.False(t, excerpt.Truncated)
+}
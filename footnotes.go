@@ -0,0 +1,168 @@
+package goeditorjs
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// footnote is a footnote of the footnotes tune, referenced by markers with its id in the inline html of the block
+type footnote struct {
+	ID      string `json:"id"`
+	Content string `json:"content"`
+}
+
+// footnoteMarkerRegexp matches the inline markers of the footnotes tune, e.g. <sup data-tune="footnotes" data-id="x">
+var footnoteMarkerRegexp = regexp.MustCompile(`(?s)<sup\b[^>]*\bdata-id="([^"]*)"[^>]*>.*?</sup>`)
+
+// collectedFootnote is a footnote numbered by a footnoteCollector
+type collectedFootnote struct {
+	Number  int
+	Content string
+	// Referenced is set if a marker of the footnote was found
+	Referenced bool
+}
+
+// footnoteCollector numbers the footnotes of a document in the order they are rendered. The engines render every
+// document with a collector of its own, so numbering is stable across the document, including nested blocks.
+type footnoteCollector struct {
+	footnotes []*collectedFootnote
+	byID      map[string]*collectedFootnote
+}
+
+func newFootnoteCollector() *footnoteCollector {
+	return &footnoteCollector{byID: map[string]*collectedFootnote{}}
+}
+
+// blockFootnotes returns the footnotes of the footnotes tune of block and their contents by id
+func blockFootnotes(block EditorJSBlock) ([]footnote, map[string]string) {
+	raw, ok := block.Tunes["footnotes"]
+	if !ok {
+		return nil, nil
+	}
+	footnotes := []footnote{}
+	if json.Unmarshal(raw, &footnotes) != nil {
+		return nil, nil
+	}
+	contents := map[string]string{}
+	for _, f := range footnotes {
+		contents[f.ID] = f.Content
+	}
+	return footnotes, contents
+}
+
+// number returns the footnote with id, numbering it if it wasn't collected before
+func (c *footnoteCollector) number(id, content string) *collectedFootnote {
+	if f, ok := c.byID[id]; ok {
+		return f
+	}
+	f := &collectedFootnote{Number: len(c.footnotes) + 1, Content: content}
+	c.footnotes = append(c.footnotes, f)
+	c.byID[id] = f
+	return f
+}
+
+// reserve numbers the footnotes referenced by the markers in the text fields of block. The engines reserve the
+// footnotes of a block before rendering the blocks nested in it, like the blocks owned by a toggle, so the markers in
+// the text of the toggle are numbered before the ones of its children.
+func (c *footnoteCollector) reserve(block EditorJSBlock) {
+	footnotes, contents := blockFootnotes(block)
+	if len(footnotes) == 0 {
+		return
+	}
+	data, err := DecodeBlock(block)
+	if err != nil {
+		return
+	}
+	for _, field := range textFields(&BlockNode{Block: block, Data: data}) {
+		for _, match := range footnoteMarkerRegexp.FindAllStringSubmatch(field.HTML, -1) {
+			if content, ok := contents[match[1]]; ok {
+				c.number(match[1], content)
+			}
+		}
+	}
+}
+
+// collect numbers the footnotes of block and replaces their markers in output by the references returned by ref.
+// first is set for the first reference of a footnote.
+func (c *footnoteCollector) collect(block EditorJSBlock, output string, ref func(number int, first bool) string) string {
+	footnotes, contents := blockFootnotes(block)
+	if len(footnotes) == 0 {
+		return output
+	}
+
+	output = footnoteMarkerRegexp.ReplaceAllStringFunc(output, func(marker string) string {
+		id := footnoteMarkerRegexp.FindStringSubmatch(marker)[1]
+		content, ok := contents[id]
+		if !ok {
+			return marker
+		}
+		f := c.number(id, content)
+		first := !f.Referenced
+		f.Referenced = true
+		return ref(f.Number, first)
+	})
+	// footnotes without marker are still listed
+	for _, f := range footnotes {
+		c.number(f.ID, f.Content)
+	}
+	return output
+}
+
+// htmlFootnoteRef returns the html reference of the footnote numbered number
+func htmlFootnoteRef(engine *HTMLEngine) func(number int, first bool) string {
+	return func(number int, first bool) string {
+		id := ""
+		if first {
+			id = fmt.Sprintf(` id="fnref-%d"`, number)
+		}
+		return fmt.Sprintf(`<sup%s><a href="#fn-%d"%s>%d</a></sup>`, classAttr(nil, engine, "footnote-ref", "footnote-ref"), number, id, number)
+	}
+}
+
+// html returns the footnote section of the collected footnotes
+func (c *footnoteCollector) html(engine *HTMLEngine) string {
+	if len(c.footnotes) == 0 {
+		return ""
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf(`<section%s><ol>`, classAttr(nil, engine, "footnotes", "footnotes")))
+	for _, f := range c.footnotes {
+		backref := ""
+		if f.Referenced {
+			backref = fmt.Sprintf(` <a href="#fnref-%d"%s>↩</a>`, f.Number, classAttr(nil, engine, "footnote-backref", "footnote-backref"))
+		}
+		sb.WriteString(fmt.Sprintf(`<li id="fn-%d">%s%s</li>`, f.Number, f.Content, backref))
+	}
+	sb.WriteString("</ol></section>")
+	return sb.String()
+}
+
+// markdownFootnoteRef returns the markdown reference of the footnote numbered number. Dialects without footnotes
+// refer to the numbered notes appended to the document.
+func markdownFootnoteRef(dialect *MarkdownDialect) func(number int, first bool) string {
+	return func(number int, first bool) string {
+		if !dialect.Footnotes {
+			return fmt.Sprintf("[%d]", number)
+		}
+		return fmt.Sprintf("[^%d]", number)
+	}
+}
+
+// markdown returns the footnote definitions of the collected footnotes, or a numbered list of them for dialects
+// without footnotes
+func (c *footnoteCollector) markdown(dialect *MarkdownDialect) string {
+	lines := make([]string, len(c.footnotes))
+	for i, f := range c.footnotes {
+		// continuation lines are indented to stay part of the definition
+		content := strings.ReplaceAll(strings.TrimSpace(markdownInline(f.Content, dialect)), "\n", "\n    ")
+		if dialect.Footnotes {
+			lines[i] = fmt.Sprintf("[^%d]: %s", f.Number, content)
+		} else {
+			lines[i] = fmt.Sprintf("%d. %s", f.Number, content)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
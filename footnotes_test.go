@@ -0,0 +1,80 @@
+package goeditorjs_test
+
+import (
+	"testing"
+
+	"github.com/davidscottmills/goeditorjs"
+	"github.com/stretchr/testify/require"
+)
+
+const footnotesData = `{"blocks": [
+	{"type": "paragraph", "data": {"text": "Go<sup data-tune=\"footnotes\" data-id=\"a\">1</sup> and Rust<sup data-tune=\"footnotes\" data-id=\"b\">2</sup>.", "alignment": "left"},
+		"tunes": {"footnotes": [{"id": "a", "content": "A <i>language</i>."}, {"id": "b", "content": "Another one."}, {"id": "c", "content": "Unreferenced."}]}},
+	{"type": "columns", "data": {"cols": [{"blocks": [
+		{"type": "paragraph", "data": {"text": "Nested<sup data-tune=\"footnotes\" data-id=\"d\">1</sup>", "alignment": "left"},
+			"tunes": {"footnotes": [{"id": "d", "content": "Line<br>break"}]}}
+	]}]}},
+	{"type": "paragraph", "data": {"text": "Unknown<sup data-id=\"x\">?</sup>", "alignment": "left"}}
+]}`
+
+func Test_HTMLEngine_Footnotes(t *testing.T) {
+	eng := goeditorjs.NewHTMLEngine()
+	eng.RegisterBlockHandlers(&goeditorjs.ParagraphHandler{}, &goeditorjs.ColumnsHandler{})
+
+	expected := `<p>Go<sup class="footnote-ref"><a href="#fn-1" id="fnref-1">1</a></sup> and Rust<sup class="footnote-ref"><a href="#fn-2" id="fnref-2">2</a></sup>.</p>` +
+		`<div class="columns"><div class="columns__column"><p>Nested<sup class="footnote-ref"><a href="#fn-4" id="fnref-4">4</a></sup></p></div></div>` +
+		`<p>Unknown<sup data-id="x">?</sup></p>` +
+		`<section class="footnotes"><ol>` +
+		`<li id="fn-1">A <i>language</i>. <a href="#fnref-1" class="footnote-backref">↩</a></li>` +
+		`<li id="fn-2">Another one. <a href="#fnref-2" class="footnote-backref">↩</a></li>` +
+		`<li id="fn-3">Unreferenced.</li>` +
+		`<li id="fn-4">Line<br>break <a href="#fnref-4" class="footnote-backref">↩</a></li>` +
+		`</ol></section>`
+	for i := 0; i < 2; i++ {
+		// numbering starts over for every document
+		result, err := eng.GenerateHTML(footnotesData)
+		require.NoError(t, err)
+		require.Equal(t, expected, result)
+	}
+}
+
+func Test_MarkdownEngine_Footnotes(t *testing.T) {
+	eng := goeditorjs.NewMarkdownEngine()
+	eng.RegisterBlockHandlers(&goeditorjs.ParagraphHandler{}, &goeditorjs.ColumnsHandler{})
+	result, err := eng.GenerateMarkdown(footnotesData)
+	require.NoError(t, err)
+	require.Equal(t, "Go[^1] and Rust[^2].\n\nNested[^4]\n\nUnknown<sup data-id=\"x\">?</sup>\n\n"+
+		"[^1]: A *language*.\n[^2]: Another one.\n[^3]: Unreferenced.\n[^4]: Line\\\n    break", result)
+
+	result, err = eng.GenerateMarkdownWithUnknownBlock(`{"blocks": [{"type": "paragraph", "data": {"text": "x<sup data-id=\"a\"></sup>"},
+		"tunes": {"footnotes": [{"id": "a", "content": "Note"}]}}]}`)
+	require.NoError(t, err)
+	require.Equal(t, "x[^1]\n\n[^1]: Note", result)
+}
+
+func Test_Engines_Footnotes_Toggle(t *testing.T) {
+	data := `{"blocks": [
+		{"type": "toggle", "data": {"text": "Title<sup data-tune=\"footnotes\" data-id=\"t\">1</sup>", "status": "open", "items": 1},
+			"tunes": {"footnotes": [{"id": "t", "content": "Title note"}]}},
+		{"type": "paragraph", "data": {"text": "Child<sup data-tune=\"footnotes\" data-id=\"c\">1</sup>", "alignment": "left"},
+			"tunes": {"footnotes": [{"id": "c", "content": "Child note"}]}}
+	]}`
+
+	htmlEngine := goeditorjs.NewHTMLEngine()
+	htmlEngine.RegisterBlockHandlers(&goeditorjs.ToggleHandler{}, &goeditorjs.ParagraphHandler{})
+	result, err := htmlEngine.GenerateHTML(data)
+	require.NoError(t, err)
+	require.Equal(t, `<details open><summary>Title<sup class="footnote-ref"><a href="#fn-1" id="fnref-1">1</a></sup></summary>`+
+		`<p>Child<sup class="footnote-ref"><a href="#fn-2" id="fnref-2">2</a></sup></p></details>`+
+		`<section class="footnotes"><ol>`+
+		`<li id="fn-1">Title note <a href="#fnref-1" class="footnote-backref">↩</a></li>`+
+		`<li id="fn-2">Child note <a href="#fnref-2" class="footnote-backref">↩</a></li>`+
+		`</ol></section>`, result)
+
+	markdownEngine := goeditorjs.NewMarkdownEngine()
+	markdownEngine.RegisterBlockHandlers(&goeditorjs.ToggleHandler{}, &goeditorjs.ParagraphHandler{})
+	result, err = markdownEngine.GenerateMarkdown(data)
+	require.NoError(t, err)
+	require.Equal(t, "<details open>\n<summary>Title[^1]</summary>\n\nChild[^2]\n\n</details>\n\n"+
+		"[^1]: Title note\n[^2]: Child note", result)
+}
@@ -0,0 +1,213 @@
+package goeditorjs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// FrontMatterFormat is the syntax of the front matter
+type FrontMatterFormat string
+
+const (
+	// FrontMatterYAML emits YAML front matter delimited by "---"
+	FrontMatterYAML FrontMatterFormat = "yaml"
+	// FrontMatterTOML emits TOML front matter delimited by "+++"
+	FrontMatterTOML FrontMatterFormat = "toml"
+)
+
+// FrontMatterOptions are the options used by the MarkdownEngine to prepend front matter to the generated markdown.
+// The front matter holds the fields derived from the document, merged with the caller's Metadata:
+//
+//	title        the plain text of the first header block
+//	description  the plain text of the first paragraph block
+//	date         the time the document was saved
+//	version      the editor.js version the document was saved with
+//	wordCount    the number of words of the text blocks
+//	readingTime  the reading time in minutes
+//
+// Derived fields without a value are omitted.
+type FrontMatterOptions struct {
+	// Format of the front matter, FrontMatterYAML if empty
+	Format FrontMatterFormat
+	// Metadata holds the caller's fields. They replace the derived fields of the same name, a nil value removes the
+	// field.
+	Metadata map[string]interface{}
+	// WordsPerMinute is the reading speed readingTime is calculated with, 200 if not set
+	WordsPerMinute int
+}
+
+// WithFrontMatter makes the MarkdownEngine prepend front matter to the generated markdown using the given options
+func WithFrontMatter(options *FrontMatterOptions) MarkdownEngineOptions {
+	return func(m *MarkdownEngine) {
+		m.FrontMatter = options
+	}
+}
+
+// frontMatterFields is the order of the derived fields, the caller's fields follow sorted by name
+var frontMatterFields = []string{"title", "description", "date", "version", "wordCount", "readingTime"}
+
+// frontMatter returns the front matter of ejs configured by options
+func frontMatter(options *FrontMatterOptions, ejs *Document) (string, error) {
+	fields := map[string]interface{}{}
+	if title := documentTitle(ejs); title != "" {
+		fields["title"] = title
+	}
+	if description := documentDescription(ejs); description != "" {
+		fields["description"] = description
+	}
+	if ejs.Time > 0 {
+		fields["date"] = time.Unix(0, ejs.Time*int64(time.Millisecond)).UTC()
+	}
+	if ejs.Version != "" {
+		fields["version"] = ejs.Version
+	}
+	if stats := ejs.Stats(&StatsOptions{WordsPerMinute: options.WordsPerMinute}); stats.Words > 0 {
+		fields["wordCount"] = stats.Words
+		fields["readingTime"] = stats.ReadingTime
+	}
+
+	keys := []string{}
+	for _, key := range frontMatterFields {
+		if _, ok := options.Metadata[key]; !ok && fields[key] != nil {
+			keys = append(keys, key)
+		}
+	}
+	metadataKeys := []string{}
+	for key, value := range options.Metadata {
+		if value != nil {
+			fields[key] = value
+			metadataKeys = append(metadataKeys, key)
+		}
+	}
+	sort.Strings(metadataKeys)
+	keys = append(keys, metadataKeys...)
+
+	sb := strings.Builder{}
+	switch options.Format {
+	case FrontMatterYAML, "":
+		sb.WriteString("---\n")
+		for _, key := range keys {
+			value, err := yamlValue(fields[key])
+			if err != nil {
+				return "", fmt.Errorf("front matter field %s: %w", key, err)
+			}
+			sb.WriteString(fmt.Sprintf("%s: %s\n", frontMatterKey(key), value))
+		}
+		sb.WriteString("---")
+	case FrontMatterTOML:
+		sb.WriteString("+++\n")
+		for _, key := range keys {
+			value, err := tomlValue(fields[key])
+			if err != nil {
+				return "", fmt.Errorf("front matter field %s: %w", key, err)
+			}
+			sb.WriteString(fmt.Sprintf("%s = %s\n", frontMatterKey(key), value))
+		}
+		sb.WriteString("+++")
+	default:
+		return "", fmt.Errorf("unknown front matter format %q", options.Format)
+	}
+	return sb.String(), nil
+}
+
+// bareKeyRegexp matches the keys that don't need quotes in YAML and TOML
+var bareKeyRegexp = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// jsonString returns s as JSON string without escaping html, which is a valid YAML and TOML string as well
+func jsonString(s string) string {
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// frontMatterKey returns key as YAML or TOML key, quoting it unless it is a bare key
+func frontMatterKey(key string) string {
+	if bareKeyRegexp.MatchString(key) {
+		return key
+	}
+	return jsonString(key)
+}
+
+// yamlValue returns value as YAML flow value. JSON is valid YAML, so everything but times is written as JSON.
+func yamlValue(value interface{}) (string, error) {
+	if t, ok := value.(time.Time); ok {
+		return t.Format(time.RFC3339), nil
+	}
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(value); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// tomlValue returns value as TOML value. Values other than times are normalized through JSON, so structs and maps
+// of any type are written as inline tables.
+func tomlValue(value interface{}) (string, error) {
+	if t, ok := value.(time.Time); ok {
+		return t.Format(time.RFC3339), nil
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	var normalized interface{}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&normalized); err != nil {
+		return "", err
+	}
+	return tomlJSONValue(normalized)
+}
+
+// tomlJSONValue returns the decoded JSON value as TOML value
+func tomlJSONValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return jsonString(v), nil
+	case bool:
+		return fmt.Sprint(v), nil
+	case json.Number:
+		return v.String(), nil
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			s, err := tomlJSONValue(item)
+			if err != nil {
+				return "", err
+			}
+			items[i] = s
+		}
+		return "[" + strings.Join(items, ", ") + "]", nil
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		items := []string{}
+		for _, key := range keys {
+			if v[key] == nil {
+				continue
+			}
+			s, err := tomlJSONValue(v[key])
+			if err != nil {
+				return "", err
+			}
+			items = append(items, fmt.Sprintf("%s = %s", frontMatterKey(key), s))
+		}
+		if len(items) == 0 {
+			return "{}", nil
+		}
+		return "{ " + strings.Join(items, ", ") + " }", nil
+	}
+	return "", fmt.Errorf("TOML can't represent %v", value)
+}
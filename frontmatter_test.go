@@ -0,0 +1,77 @@
+package goeditorjs_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/davidscottmills/goeditorjs"
+	"github.com/stretchr/testify/require"
+)
+
+const frontMatterData = `{"time": 1700000000000, "version": "2.28.2", "blocks": [
+	{"type": "header", "data": {"text": "Hello <i>World</i>", "level": 1}},
+	{"type": "paragraph", "data": {"text": "A &quot;short&quot; intro.", "alignment": "left"}},
+	{"type": "list", "data": {"style": "unordered", "items": ["one<br>two", "three"]}}
+]}`
+
+func frontMatterEngine(options *goeditorjs.FrontMatterOptions) *goeditorjs.MarkdownEngine {
+	eng := goeditorjs.NewMarkdownEngine(goeditorjs.WithFrontMatter(options))
+	eng.RegisterBlockHandlers(&goeditorjs.HeaderHandler{}, &goeditorjs.ParagraphHandler{}, &goeditorjs.ListHandler{})
+	return eng
+}
+
+func Test_MarkdownEngine_FrontMatter_YAML(t *testing.T) {
+	eng := frontMatterEngine(&goeditorjs.FrontMatterOptions{Metadata: map[string]interface{}{
+		"tags":        []string{"go", "editor.js"},
+		"draft":       true,
+		"description": nil,
+		"my key":      "<value>",
+	}})
+	result, err := eng.GenerateMarkdown(frontMatterData)
+	require.NoError(t, err)
+	require.Equal(t, "---\n"+
+		"title: \"Hello World\"\n"+
+		"date: 2023-11-14T22:13:20Z\n"+
+		"version: \"2.28.2\"\n"+
+		"wordCount: 8\n"+
+		"readingTime: 1\n"+
+		"draft: true\n"+
+		"\"my key\": \"<value>\"\n"+
+		"tags: [\"go\",\"editor.js\"]\n"+
+		"---\n\n"+
+		"# Hello *World*\n\nA \"short\" intro.\n\n- one\\\n  two\n- three", result)
+}
+
+func Test_MarkdownEngine_FrontMatter_TOML(t *testing.T) {
+	eng := frontMatterEngine(&goeditorjs.FrontMatterOptions{
+		Format:         goeditorjs.FrontMatterTOML,
+		WordsPerMinute: 4,
+		Metadata: map[string]interface{}{
+			"title":   "Override",
+			"params":  map[string]interface{}{"weight": 2, "series": []string{"a"}},
+			"lastmod": time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		},
+	})
+	result, err := eng.GenerateMarkdownWithUnknownBlock(frontMatterData)
+	require.NoError(t, err)
+	require.Equal(t, "+++\n"+
+		"description = \"A \\\"short\\\" intro.\"\n"+
+		"date = 2023-11-14T22:13:20Z\n"+
+		"version = \"2.28.2\"\n"+
+		"wordCount = 8\n"+
+		"readingTime = 2\n"+
+		"lastmod = 2024-01-02T03:04:05Z\n"+
+		"params = { series = [\"a\"], weight = 2 }\n"+
+		"title = \"Override\"\n"+
+		"+++\n\n"+
+		"# Hello *World*\n\nA \"short\" intro.\n\n- one\\\n  two\n- three", result)
+}
+
+func Test_MarkdownEngine_FrontMatter_Errors(t *testing.T) {
+	_, err := frontMatterEngine(&goeditorjs.FrontMatterOptions{Format: "json"}).GenerateMarkdown(frontMatterData)
+	require.Error(t, err)
+
+	_, err = frontMatterEngine(&goeditorjs.FrontMatterOptions{Format: goeditorjs.FrontMatterTOML,
+		Metadata: map[string]interface{}{"list": []interface{}{nil}}}).GenerateMarkdown(frontMatterData)
+	require.Error(t, err)
+}
@@ -1,5 +1,8 @@
 module github.com/davidscottmills/goeditorjs
 
-go 1.15
+go 1.16
 
-require github.com/stretchr/testify v1.6.1
+require (
+	github.com/stretchr/testify v1.6.1
+	github.com/yuin/goldmark v1.4.12
+)
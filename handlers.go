@@ -3,16 +3,20 @@ package goeditorjs
 import (
 	"encoding/json"
 	"fmt"
+	"html"
+	"math"
 	"regexp"
-	"sort"
 	"strings"
 )
 
 // HeaderHandler is the default HeaderHandler for EditorJS HTML generation
-type HeaderHandler struct{}
+type HeaderHandler struct {
+	// Classes override the engine's ClassMap for the elements this handler emits
+	Classes ClassMap
+}
 
-func (*HeaderHandler) parse(editorJSBlock EditorJSBlock) (*header, error) {
-	header := &header{}
+func (*HeaderHandler) parse(editorJSBlock EditorJSBlock) (*Header, error) {
+	header := &Header{}
 	return header, json.Unmarshal(editorJSBlock.Data, header)
 }
 
@@ -23,31 +27,56 @@ func (*HeaderHandler) Type() string {
 
 // GenerateHTML generates html for HeaderBlocks
 func (h *HeaderHandler) GenerateHTML(editorJSBlock EditorJSBlock) (string, error) {
+	return h.GenerateHTMLWithEngine(editorJSBlock, nil)
+}
+
+// GenerateHTMLWithEngine generates html for HeaderBlocks using the engine's ClassMap
+func (h *HeaderHandler) GenerateHTMLWithEngine(editorJSBlock EditorJSBlock, engine *HTMLEngine) (string, error) {
 	header, err := h.parse(editorJSBlock)
 	if err != nil {
 		return "", err
 	}
 
-	return fmt.Sprintf("<h%d>%s</h%d>", header.Level, header.Text, header.Level), nil
+	class := classAttr(h.Classes, engine, fmt.Sprintf("h%d", header.Level))
+	return fmt.Sprintf("<h%d%s>%s</h%d>", header.Level, class, header.Text, header.Level), nil
 }
 
 // GenerateMarkdown generates markdown for HeaderBlocks
 func (h *HeaderHandler) GenerateMarkdown(editorJSBlock EditorJSBlock) (string, error) {
+	return h.GenerateMarkdownWithEngine(editorJSBlock, nil)
+}
+
+// GenerateMarkdownWithEngine generates markdown for HeaderBlocks in the engine's dialect
+func (h *HeaderHandler) GenerateMarkdownWithEngine(editorJSBlock EditorJSBlock, engine *MarkdownEngine) (string, error) {
 	header, err := h.parse(editorJSBlock)
 	if err != nil {
 		return "", err
 	}
 
-	return fmt.Sprintf("%s %s", strings.Repeat("#", header.Level), header.Text), nil
+	// a heading is a single line, and a trailing run of # would be taken as its closing sequence
+	text := markdownInlineLine(header.Text, " ", engine.dialect())
+	if m := trailingHashesRegexp.FindStringSubmatchIndex(text); m != nil {
+		text = text[:m[2]] + `\` + text[m[2]:]
+	}
+	return fmt.Sprintf("%s %s", strings.Repeat("#", header.Level), text), nil
 }
 
-// Table
-type TableHandler struct{}
+// trailingHashesRegexp matches a run of # at the end of a heading that would be taken as closing sequence
+var trailingHashesRegexp = regexp.MustCompile(`(?:^|[ \t])(#+)[ \t]*$`)
+
+// TableHandler is the default TableHandler for EditorJS HTML and markdown generation
+type TableHandler struct {
+	// Classes override the engine's ClassMap for the elements this handler emits
+	Classes ClassMap
+}
 
+// Table represents table data from EditorJS
 type Table struct {
-	Content [][]string `json:"content"`
+	WithHeadings bool       `json:"withHeadings"`
+	Content      [][]string `json:"content"`
 }
 
+// Type "table"
 func (*TableHandler) Type() string {
 	return "table"
 }
@@ -57,19 +86,84 @@ func (*TableHandler) parse(editorJSBlock EditorJSBlock) (*Table, error) {
 	return table, json.Unmarshal(editorJSBlock.Data, table)
 }
 
-// GenerateMarkdown generates markdown for ParagraphBlocks
+// GenerateHTML generates html for TableBlocks
+func (h *TableHandler) GenerateHTML(editorJSBlock EditorJSBlock) (string, error) {
+	return h.GenerateHTMLWithEngine(editorJSBlock, nil)
+}
+
+// GenerateHTMLWithEngine generates html for TableBlocks using the engine's ClassMap
+func (h *TableHandler) GenerateHTMLWithEngine(editorJSBlock EditorJSBlock, engine *HTMLEngine) (string, error) {
+	table, err := h.parse(editorJSBlock)
+	if err != nil {
+		return "", err
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("<table%s>", classAttr(h.Classes, engine, "table")))
+	tr := classAttr(h.Classes, engine, "tr")
+	for i, row := range table.Content {
+		cell := "td"
+		if table.WithHeadings && i == 0 {
+			cell = "th"
+		}
+		class := classAttr(h.Classes, engine, cell)
+
+		sb.WriteString(fmt.Sprintf("<tr%s>", tr))
+		for _, c := range row {
+			sb.WriteString(fmt.Sprintf("<%s%s>%s</%s>", cell, class, c, cell))
+		}
+		sb.WriteString("</tr>")
+	}
+	sb.WriteString("</table>")
+
+	return sb.String(), nil
+}
+
+// GenerateMarkdown generates markdown for TableBlocks
 func (h *TableHandler) GenerateMarkdown(editorJSBlock EditorJSBlock) (string, error) {
+	return h.GenerateMarkdownWithEngine(editorJSBlock, nil)
+}
+
+// GenerateMarkdownWithEngine generates markdown for TableBlocks in the engine's dialect. Dialects without tables get
+// an html table if they permit html, and a list of rows otherwise.
+func (h *TableHandler) GenerateMarkdownWithEngine(editorJSBlock EditorJSBlock, engine *MarkdownEngine) (string, error) {
 	table, err := h.parse(editorJSBlock)
 	if err != nil {
 		return "", err
 	}
 
+	dialect := engine.dialect()
+	if !dialect.Tables {
+		if dialect.HTML {
+			return h.GenerateHTML(editorJSBlock)
+		}
+		rows := make([]string, len(table.Content))
+		for i, row := range table.Content {
+			cells := make([]string, len(row))
+			for j, c := range row {
+				cells[j] = markdownInlineLine(c, " ", dialect)
+			}
+			rows[i] = markdownListItem("- ", strings.Join(cells, " | "))
+		}
+		return strings.Join(rows, "\n"), nil
+	}
+
+	// line breaks can't be expressed in pipe tables without html
+	lineBreak := " "
+	if dialect.HTML {
+		lineBreak = "<br>"
+	}
 	var sb strings.Builder
 
 	// 遍历数据，生成表头和表格行
 	for i, row := range table.Content {
+		cells := make([]string, len(row))
+		for j, c := range row {
+			cells[j] = markdownInlineLine(c, lineBreak, dialect)
+			cells[j] = strings.ReplaceAll(cells[j], "|", `\|`)
+		}
 		// 将每一行的元素连接成表格单元格
-		sb.WriteString("| " + strings.Join(row, " | ") + " |\n")
+		sb.WriteString("| " + strings.Join(cells, " | ") + " |\n")
 
 		// 只在第二行之后加上表格分隔线
 		if i == 0 {
@@ -85,10 +179,13 @@ func (h *TableHandler) GenerateMarkdown(editorJSBlock EditorJSBlock) (string, er
 }
 
 // ParagraphHandler is the default ParagraphHandler for EditorJS HTML generation
-type ParagraphHandler struct{}
+type ParagraphHandler struct {
+	// Classes override the engine's ClassMap for the elements this handler emits
+	Classes ClassMap
+}
 
-func (*ParagraphHandler) parse(editorJSBlock EditorJSBlock) (*paragraph, error) {
-	paragraph := &paragraph{}
+func (*ParagraphHandler) parse(editorJSBlock EditorJSBlock) (*Paragraph, error) {
+	paragraph := &Paragraph{}
 	return paragraph, json.Unmarshal(editorJSBlock.Data, paragraph)
 }
 
@@ -99,34 +196,50 @@ func (*ParagraphHandler) Type() string {
 
 // GenerateHTML generates html for ParagraphBlocks
 func (h *ParagraphHandler) GenerateHTML(editorJSBlock EditorJSBlock) (string, error) {
+	return h.GenerateHTMLWithEngine(editorJSBlock, nil)
+}
+
+// GenerateHTMLWithEngine generates html for ParagraphBlocks using the engine's ClassMap
+func (h *ParagraphHandler) GenerateHTMLWithEngine(editorJSBlock EditorJSBlock, engine *HTMLEngine) (string, error) {
 	paragraph, err := h.parse(editorJSBlock)
 	if err != nil {
 		return "", err
 	}
 
+	class := classAttr(h.Classes, engine, "p")
 	if paragraph.Alignment != "left" {
-		return fmt.Sprintf(`<p style="text-align:%s">%s</p>`, paragraph.Alignment, paragraph.Text), nil
+		return fmt.Sprintf(`<p%s style="text-align:%s">%s</p>`, class, paragraph.Alignment, paragraph.Text), nil
 	}
 
-	return fmt.Sprintf(`<p>%s</p>`, paragraph.Text), nil
+	return fmt.Sprintf(`<p%s>%s</p>`, class, paragraph.Text), nil
 }
 
 // GenerateMarkdown generates markdown for ParagraphBlocks
 func (h *ParagraphHandler) GenerateMarkdown(editorJSBlock EditorJSBlock) (string, error) {
+	return h.GenerateMarkdownWithEngine(editorJSBlock, nil)
+}
+
+// GenerateMarkdownWithEngine generates markdown for ParagraphBlocks in the engine's dialect. Markdown doesn't support
+// alignment, so aligned paragraphs are generated as html or with an attribute list if the dialect permits it.
+func (h *ParagraphHandler) GenerateMarkdownWithEngine(editorJSBlock EditorJSBlock, engine *MarkdownEngine) (string, error) {
 	paragraph, err := h.parse(editorJSBlock)
 	if err != nil {
 		return "", err
 	}
 
-	// if paragraph.Alignment != "left" {
-	// 	// Native markdown doesn't support alignment, so we'll use html instead.
-	// 	return fmt.Sprintf(`<p style="text-align:%s">%s</p>`, paragraph.Alignment, paragraph.Text), nil
-	// }
-
-	paragraph.Text = ParseTextATags(paragraph.Text)
-	paragraph.Text = ParseTextCodeTags(paragraph.Text)
+	dialect := engine.dialect()
+	aligned := paragraph.Alignment != "" && paragraph.Alignment != "left"
+	if aligned && dialect.Attributes == AttributesNone && dialect.HTML {
+		return fmt.Sprintf(`<p style="text-align:%s">%s</p>`, paragraph.Alignment, paragraph.Text), nil
+	}
 
-	return paragraph.Text, nil
+	text := markdownInline(paragraph.Text, engine.dialect())
+	if aligned {
+		if attrs := dialect.attributes(nil, "text-align:"+paragraph.Alignment); attrs != "" {
+			return text + "\n" + attrs, nil
+		}
+	}
+	return text, nil
 }
 
 // parse a tag to markdown fmt: []()
@@ -169,10 +282,13 @@ func ParseTextATags(input string) string {
 }
 
 // ListHandler is the default ListHandler for EditorJS HTML generation
-type ListHandler struct{}
+type ListHandler struct {
+	// Classes override the engine's ClassMap for the elements this handler emits
+	Classes ClassMap
+}
 
-func (*ListHandler) parse(editorJSBlock EditorJSBlock) (*list, error) {
-	list := &list{}
+func (*ListHandler) parse(editorJSBlock EditorJSBlock) (*List, error) {
+	list := &List{}
 	return list, json.Unmarshal(editorJSBlock.Data, list)
 }
 
@@ -183,59 +299,164 @@ func (*ListHandler) Type() string {
 
 // GenerateHTML generates html for ListBlocks
 func (h *ListHandler) GenerateHTML(editorJSBlock EditorJSBlock) (string, error) {
+	return h.GenerateHTMLWithEngine(editorJSBlock, nil)
+}
+
+// GenerateHTMLWithEngine generates html for ListBlocks using the engine's ClassMap
+func (h *ListHandler) GenerateHTMLWithEngine(editorJSBlock EditorJSBlock, engine *HTMLEngine) (string, error) {
 	list, err := h.parse(editorJSBlock)
 	if err != nil {
 		return "", err
 	}
 
-	result := ""
+	tag := "ul"
 	if list.Style == "ordered" {
-		result = "<ol>%s</ol>"
-	} else {
-		result = "<ul>%s</ul>"
+		tag = "ol"
 	}
 
 	innerData := ""
+	li := classAttr(h.Classes, engine, "li")
 	for _, s := range list.Items {
-		innerData += fmt.Sprintf("<li>%s</li>", s)
+		innerData += fmt.Sprintf("<li%s>%s</li>", li, s)
 	}
 
-	return fmt.Sprintf(result, innerData), nil
+	return fmt.Sprintf("<%s%s>%s</%s>", tag, classAttr(h.Classes, engine, tag), innerData, tag), nil
 }
 
 // GenerateMarkdown generates markdown for ListBlocks
 func (h *ListHandler) GenerateMarkdown(editorJSBlock EditorJSBlock) (string, error) {
+	return h.GenerateMarkdownWithEngine(editorJSBlock, nil)
+}
+
+// GenerateMarkdownWithEngine generates markdown for ListBlocks in the engine's dialect
+func (h *ListHandler) GenerateMarkdownWithEngine(editorJSBlock EditorJSBlock, engine *MarkdownEngine) (string, error) {
 	list, err := h.parse(editorJSBlock)
 	if err != nil {
 		return "", err
 	}
 
-	listItemPrefix := "- "
+	// ordered items are all numbered 1, which markdown renumbers, so items can be moved without renumbering
+	marker := "- "
+	if list.Style == "ordered" {
+		marker = "1. "
+	}
 
 	results := []string{}
-	for i, s := range list.Items {
-		if list.Style == "ordered" {
-			listItemPrefix = fmt.Sprintf("%d.", i+1)
-		}
-		results = append(results, listItemPrefix+s)
+	for _, s := range list.Items {
+		results = append(results, markdownListItem(marker, markdownInline(s, engine.dialect())))
 	}
 
 	return strings.Join(results, "\n"), nil
 }
 
+// QuoteHandler is the default QuoteHandler for EditorJS HTML and markdown generation
+type QuoteHandler struct {
+	// Classes override the engine's ClassMap for the elements this handler emits
+	Classes ClassMap
+}
+
+func (*QuoteHandler) parse(editorJSBlock EditorJSBlock) (*Quote, error) {
+	quote := &Quote{}
+	return quote, json.Unmarshal(editorJSBlock.Data, quote)
+}
+
+// Type "quote"
+func (*QuoteHandler) Type() string {
+	return "quote"
+}
+
+// GenerateHTML generates html for QuoteBlocks
+func (h *QuoteHandler) GenerateHTML(editorJSBlock EditorJSBlock) (string, error) {
+	return h.GenerateHTMLWithEngine(editorJSBlock, nil)
+}
+
+// GenerateHTMLWithEngine generates html for QuoteBlocks using the engine's ClassMap
+func (h *QuoteHandler) GenerateHTMLWithEngine(editorJSBlock EditorJSBlock, engine *HTMLEngine) (string, error) {
+	quote, err := h.parse(editorJSBlock)
+	if err != nil {
+		return "", err
+	}
+
+	style := ""
+	if quote.Alignment != "" && quote.Alignment != "left" {
+		style = fmt.Sprintf(` style="text-align:%s"`, quote.Alignment)
+	}
+	caption := ""
+	if quote.Caption != "" {
+		caption = fmt.Sprintf("<cite%s>%s</cite>", classAttr(h.Classes, engine, "cite"), quote.Caption)
+	}
+
+	return fmt.Sprintf("<blockquote%s%s>%s%s</blockquote>", classAttr(h.Classes, engine, "blockquote"), style, quote.Text, caption), nil
+}
+
+// GenerateMarkdown generates markdown for QuoteBlocks
+func (h *QuoteHandler) GenerateMarkdown(editorJSBlock EditorJSBlock) (string, error) {
+	return h.GenerateMarkdownWithEngine(editorJSBlock, nil)
+}
+
+// GenerateMarkdownWithEngine generates markdown for QuoteBlocks in the engine's dialect
+func (h *QuoteHandler) GenerateMarkdownWithEngine(editorJSBlock EditorJSBlock, engine *MarkdownEngine) (string, error) {
+	quote, err := h.parse(editorJSBlock)
+	if err != nil {
+		return "", err
+	}
+
+	lines := strings.Split(markdownInline(quote.Text, engine.dialect()), "\n")
+	if quote.Caption != "" {
+		lines = append(lines, "", "— "+markdownInlineLine(quote.Caption, " ", engine.dialect()))
+	}
+	for i, line := range lines {
+		lines[i] = strings.TrimRight("> "+line, " ")
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+// CodeHandler is the default CodeHandler for the plain text code blocks of EditorJS
 type CodeHandler struct {
 	CodeBoxHandler
 }
 
+// Type "code"
 func (*CodeHandler) Type() string {
 	return "code"
 }
 
+// GenerateHTML generates html for CodeBlocks
+func (h *CodeHandler) GenerateHTML(editorJSBlock EditorJSBlock) (string, error) {
+	return h.GenerateHTMLWithEngine(editorJSBlock, nil)
+}
+
+// GenerateHTMLWithEngine generates html for CodeBlocks using the engine's ClassMap
+func (h *CodeHandler) GenerateHTMLWithEngine(editorJSBlock EditorJSBlock, engine *HTMLEngine) (string, error) {
+	code, err := h.parse(editorJSBlock)
+	if err != nil {
+		return "", err
+	}
+
+	return h.generateHTML(code.Code, code.Language, engine)
+}
+
 // CodeBoxHandler is the default CodeBoxHandler for EditorJS HTML generation
-type CodeBoxHandler struct{}
+type CodeBoxHandler struct {
+	// Options are made available to the GenerateHTML function.
+	// If not provided, the code is escaped without highlighting.
+	Options *CodeBoxHandlerOptions
+	// Classes override the engine's ClassMap for the elements this handler emits
+	Classes ClassMap
+}
 
-func (*CodeBoxHandler) parse(editorJSBlock EditorJSBlock) (*codeBox, error) {
-	codeBox := &codeBox{}
+// CodeBoxHandlerOptions are the options available to the CodeBoxHandler and CodeHandler
+type CodeBoxHandlerOptions struct {
+	// Highlighter highlights the code, e.g. &BuiltinHighlighter{}. If nil, the code is escaped only.
+	Highlighter Highlighter
+	// LineNumbers wraps every line into a span with the class "line" that starts with a span with the class
+	// "line-number". Lines given in the language, e.g. "go {1,3-5}", get the class "highlighted" in any case.
+	LineNumbers bool
+}
+
+func (*CodeBoxHandler) parse(editorJSBlock EditorJSBlock) (*CodeBox, error) {
+	codeBox := &CodeBox{}
 	return codeBox, json.Unmarshal(editorJSBlock.Data, codeBox)
 }
 
@@ -246,12 +467,45 @@ func (*CodeBoxHandler) Type() string {
 
 // GenerateHTML generates html for CodeBoxBlocks
 func (h *CodeBoxHandler) GenerateHTML(editorJSBlock EditorJSBlock) (string, error) {
+	return h.GenerateHTMLWithEngine(editorJSBlock, nil)
+}
+
+// GenerateHTMLWithEngine generates html for CodeBoxBlocks using the engine's ClassMap
+func (h *CodeBoxHandler) GenerateHTMLWithEngine(editorJSBlock EditorJSBlock, engine *HTMLEngine) (string, error) {
 	codeBox, err := h.parse(editorJSBlock)
 	if err != nil {
 		return "", err
 	}
 
-	return fmt.Sprintf(`<pre><code class="%s">%s</code></pre>`, codeBox.Language, codeBox.Code), nil
+	return h.generateHTML(codeBoxText(codeBox.Code), codeBox.Language, engine)
+}
+
+// generateHTML generates the html of plain text code
+func (h *CodeBoxHandler) generateHTML(code, info string, engine *HTMLEngine) (string, error) {
+	options := h.Options
+	if options == nil {
+		options = &CodeBoxHandlerOptions{}
+	}
+
+	language, highlighted := parseCodeInfo(info)
+	if diagramLanguages[language] {
+		return renderDiagram(code, language, h.Classes, engine)
+	}
+
+	content := escapeCode(code)
+	if options.Highlighter != nil {
+		var err error
+		if content, err = options.Highlighter.Highlight(code, language); err != nil {
+			return "", err
+		}
+	}
+	if options.LineNumbers || len(highlighted) > 0 {
+		content = highlightLines(content, options.LineNumbers, highlighted)
+	}
+
+	pre := classAttr(h.Classes, engine, "pre")
+	class := classAttr(h.Classes, engine, "code", language)
+	return fmt.Sprintf(`<pre%s><code%s>%s</code></pre>`, pre, class, content), nil
 }
 
 // GenerateMarkdown generates markdown for CodeBoxBlocks
@@ -261,29 +515,33 @@ func (h *CodeBoxHandler) GenerateMarkdown(editorJSBlock EditorJSBlock) (string,
 		return "", err
 	}
 
-	codeBox.Code = strings.ReplaceAll(codeBox.Code, "<div>", "\n")
-	codeBox.Code = removeHTMLTags(codeBox.Code)
+	return codeFence(codeBoxText(codeBox.Code), codeBox.Language), nil
+}
+
+// GenerateMarkdown generates markdown for CodeBlocks
+func (h *CodeHandler) GenerateMarkdown(editorJSBlock EditorJSBlock) (string, error) {
+	code, err := h.parse(editorJSBlock)
+	if err != nil {
+		return "", err
+	}
 
-	return fmt.Sprintf("```%s\n%s\n```", codeBox.Language, codeBox.Code), nil
+	return codeFence(code.Code, code.Language), nil
 }
 
-func removeHTMLTags(in string) string {
-	// regex to match html tag
-	const pattern = `(<\/?[a-zA-A]+?[^>]*\/?>)*`
-	r := regexp.MustCompile(pattern)
-	groups := r.FindAllString(in, -1)
-	// should replace long string first
-	sort.Slice(groups, func(i, j int) bool {
-		return len(groups[i]) > len(groups[j])
-	})
-	for _, group := range groups {
-		if strings.TrimSpace(group) != "" {
-			in = strings.ReplaceAll(in, group, "")
+// codeFence returns code as fenced code block. The fence is longer than any backtick run in code.
+func codeFence(code, language string) string {
+	fence := "```"
+	for _, run := range backtickRunRegexp.FindAllString(code, -1) {
+		if len(run) >= len(fence) {
+			fence = strings.Repeat("`", len(run)+1)
 		}
 	}
-	return in
+
+	return fmt.Sprintf("%s%s\n%s\n%s", fence, codeInfoString(language), code, fence)
 }
 
+var backtickRunRegexp = regexp.MustCompile("`+")
+
 // RawHTMLHandler is the default raw handler for EditorJS HTML generation
 type RawHTMLHandler struct{}
 
@@ -300,11 +558,21 @@ func (h *RawHTMLHandler) GenerateHTML(editorJSBlock EditorJSBlock) (string, erro
 
 // GenerateMarkdown generates markdown for rawBlocks
 func (h *RawHTMLHandler) GenerateMarkdown(editorJSBlock EditorJSBlock) (string, error) {
-	return h.raw(editorJSBlock)
+	return h.GenerateMarkdownWithEngine(editorJSBlock, nil)
+}
+
+// GenerateMarkdownWithEngine generates markdown for rawBlocks. The html is passed through if the engine's dialect
+// permits html, and shown as html code block otherwise.
+func (h *RawHTMLHandler) GenerateMarkdownWithEngine(editorJSBlock EditorJSBlock, engine *MarkdownEngine) (string, error) {
+	raw, err := h.raw(editorJSBlock)
+	if err != nil || engine.dialect().HTML {
+		return raw, err
+	}
+	return codeFence(raw, "html"), nil
 }
 
 func (h *RawHTMLHandler) raw(editorJSBlock EditorJSBlock) (string, error) {
-	raw := &raw{}
+	raw := &Raw{}
 	err := json.Unmarshal(editorJSBlock.Data, raw)
 	if err != nil {
 		return "", err
@@ -318,13 +586,32 @@ type ImageHandler struct {
 	// Options are made available to the GenerateHTML and GenerateMarkdown functions.
 	// If not provided, DefaultImageHandlerOptions will be used.
 	Options *ImageHandlerOptions
+	// Classes override the engine's ClassMap for the elements this handler emits
+	Classes ClassMap
 }
 
+// ImageResizer returns the url of the image at url resized to width pixels, e.g. by adding a query parameter
+// understood by an image CDN
+type ImageResizer func(url string, width int) string
+
 // ImageHandlerOptions are the options available to the ImageHandler
 type ImageHandlerOptions struct {
 	BorderClass     string
 	StretchClass    string
 	BackgroundClass string
+	// RewriteURL rewrites the image url, e.g. StaticDomainRewriter("https://cdn.example.com")
+	RewriteURL URLRewriter
+	// Figure wraps the image into a figure element with the caption as its figcaption
+	Figure bool
+	// Resizer generates the urls of the srcset attribute for every width of SrcSetWidths
+	Resizer      ImageResizer
+	SrcSetWidths []int
+	// Sizes is the value of the sizes attribute, it is only emitted along with a srcset
+	Sizes string
+	// Loading is the value of the loading attribute, e.g. "lazy"
+	Loading string
+	// Decoding is the value of the decoding attribute, e.g. "async"
+	Decoding string
 }
 
 // DefaultImageHandlerOptions are the default options available to the ImageHandler
@@ -333,9 +620,20 @@ var DefaultImageHandlerOptions = &ImageHandlerOptions{
 	BorderClass:     "image-tool--withBorder",
 	BackgroundClass: "image-tool--withBackground"}
 
-func (*ImageHandler) parse(editorJSBlock EditorJSBlock) (*image, error) {
-	image := &image{}
-	return image, json.Unmarshal(editorJSBlock.Data, image)
+func (h *ImageHandler) parse(editorJSBlock EditorJSBlock) (*Image, error) {
+	if h.Options == nil {
+		h.Options = DefaultImageHandlerOptions
+	}
+
+	image := &Image{}
+	if err := json.Unmarshal(editorJSBlock.Data, image); err != nil {
+		return nil, err
+	}
+	if image.File.URL == "" {
+		image.File.URL = image.URL
+	}
+	image.File.URL = h.Options.RewriteURL.rewrite(image.File.URL)
+	return image, nil
 }
 
 // Type "image"
@@ -345,33 +643,47 @@ func (*ImageHandler) Type() string {
 
 // GenerateHTML generates html for ImageBlocks
 func (h *ImageHandler) GenerateHTML(editorJSBlock EditorJSBlock) (string, error) {
+	return h.GenerateHTMLWithEngine(editorJSBlock, nil)
+}
+
+// GenerateHTMLWithEngine generates html for ImageBlocks using the engine's ClassMap
+func (h *ImageHandler) GenerateHTMLWithEngine(editorJSBlock EditorJSBlock, engine *HTMLEngine) (string, error) {
 	image, err := h.parse(editorJSBlock)
 	if err != nil {
 		return "", err
 	}
 
-	return h.generateHTML(image)
+	return h.generateHTML(image, engine)
 }
 
 // GenerateMarkdown generates markdown for ImageBlocks
 func (h *ImageHandler) GenerateMarkdown(editorJSBlock EditorJSBlock) (string, error) {
+	return h.GenerateMarkdownWithEngine(editorJSBlock, nil)
+}
+
+// GenerateMarkdownWithEngine generates markdown for ImageBlocks in the engine's dialect. Stretched images and images
+// with border or background get their classes by an attribute list or, if the dialect permits html, an img element.
+func (h *ImageHandler) GenerateMarkdownWithEngine(editorJSBlock EditorJSBlock, engine *MarkdownEngine) (string, error) {
 	image, err := h.parse(editorJSBlock)
 	if err != nil {
 		return "", err
 	}
 
-	if image.Stretched || image.WithBackground || image.WithBorder {
-		return h.generateHTML(image)
+	dialect := engine.dialect()
+	classes := h.classes(image)
+	if len(classes) > 0 && dialect.Attributes == AttributesNone && dialect.HTML {
+		return h.generateHTML(image, nil)
 	}
-	return fmt.Sprintf(`![alt text](%s "%s")`, image.File.URL, image.Caption), nil
 
-}
-
-func (h *ImageHandler) generateHTML(image *image) (string, error) {
-	if h.Options == nil {
-		h.Options = DefaultImageHandlerOptions
+	md := fmt.Sprintf(`![%s](%s)`, escapeMarkdown(strings.TrimSpace(plainText(image.Caption))), markdownURL(image.File.URL))
+	if attrs := dialect.attributes(classes, ""); attrs != "" {
+		md += "\n" + attrs
 	}
+	return md, nil
+}
 
+// classes returns the option classes of the stretched, withBorder and withBackground settings of image
+func (h *ImageHandler) classes(image *Image) []string {
 	classes := []string{}
 	if image.Stretched {
 		classes = append(classes, h.Options.StretchClass)
@@ -384,11 +696,54 @@ func (h *ImageHandler) generateHTML(image *image) (string, error) {
 	if image.WithBackground {
 		classes = append(classes, h.Options.BackgroundClass)
 	}
+	return classes
+}
+
+func (h *ImageHandler) generateHTML(image *Image, engine *HTMLEngine) (string, error) {
+	classes := h.classes(image)
+	attrs := []string{
+		fmt.Sprintf(`src="%s"`, html.EscapeString(image.File.URL)),
+		fmt.Sprintf(`alt="%s"`, html.EscapeString(strings.TrimSpace(plainText(image.Caption)))),
+	}
+	if h.Options.Resizer != nil && len(h.Options.SrcSetWidths) > 0 {
+		srcset := make([]string, len(h.Options.SrcSetWidths))
+		for i, width := range h.Options.SrcSetWidths {
+			srcset[i] = fmt.Sprintf("%s %dw", h.Options.Resizer(image.File.URL, width), width)
+		}
+		attrs = append(attrs, fmt.Sprintf(`srcset="%s"`, html.EscapeString(strings.Join(srcset, ", "))))
+		if h.Options.Sizes != "" {
+			attrs = append(attrs, fmt.Sprintf(`sizes="%s"`, html.EscapeString(h.Options.Sizes)))
+		}
+	}
+	if image.File.Width > 0 && image.File.Height > 0 {
+		attrs = append(attrs, fmt.Sprintf(`width="%.0f" height="%.0f"`, math.Round(image.File.Width), math.Round(image.File.Height)))
+	}
+	if h.Options.Loading != "" {
+		attrs = append(attrs, fmt.Sprintf(`loading="%s"`, html.EscapeString(h.Options.Loading)))
+	}
+	if h.Options.Decoding != "" {
+		attrs = append(attrs, fmt.Sprintf(`decoding="%s"`, html.EscapeString(h.Options.Decoding)))
+	}
+	class := strings.TrimPrefix(classAttr(h.Classes, engine, "img", classes...), " ")
+	img := fmt.Sprintf(`<img %s %s/>`, strings.Join(attrs, " "), class)
 
-	class := ""
-	if len(classes) > 0 {
-		class = fmt.Sprintf(`class="%s"`, strings.Join(classes, " "))
+	if !h.Options.Figure {
+		return img, nil
 	}
+	figcaption := ""
+	if strings.TrimSpace(image.Caption) != "" {
+		figcaption = fmt.Sprintf(`<figcaption%s>%s</figcaption>`, classAttr(h.Classes, engine, "figcaption"), image.Caption)
+	}
+	return fmt.Sprintf(`<figure%s>%s%s</figure>`, classAttr(h.Classes, engine, "figure"), img, figcaption), nil
+}
+
+// SimpleImageHandler is the ImageHandler for blocks of the SimpleImage tool, whose data has the image url in "url"
+// instead of "file.url"
+type SimpleImageHandler struct {
+	ImageHandler
+}
 
-	return fmt.Sprintf(`<img src="%s" alt="%s" %s/>`, image.File.URL, image.Caption, class), nil
+// Type "simpleImage"
+func (*SimpleImageHandler) Type() string {
+	return "simpleImage"
 }
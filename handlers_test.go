@@ -330,6 +330,15 @@ func Test_ImageHandler_GenerateHTML(t *testing.T) {
 		// No classes
 		{data: `{"file":{"url": "https://www.w3schools.com/html/pic_trulli.jpg"},"caption": "","withBorder": false,"stretched": false,"withBackground": false}`,
 			expectedResult: `<img src="https://www.w3schools.com/html/pic_trulli.jpg" alt="" />`},
+		// Escaped alt text from the caption's plain text and dimensions
+		{data: `{"file":{"url": "https://www.w3schools.com/html/pic_trulli.jpg?a=1&b=2", "width": 640, "height": 480},"caption": "<b>Trulli</b> \"house\""}`,
+			expectedResult: `<img src="https://www.w3schools.com/html/pic_trulli.jpg?a=1&amp;b=2" alt="Trulli &#34;house&#34;" width="640" height="480" />`},
+		// SimpleImage data
+		{data: `{"url": "https://www.w3schools.com/html/pic_trulli.jpg","caption": "Trulli"}`,
+			expectedResult: `<img src="https://www.w3schools.com/html/pic_trulli.jpg" alt="Trulli" />`},
+		// Fractional dimensions are rounded
+		{data: `{"file":{"url": "https://www.w3schools.com/html/pic_trulli.jpg", "width": 640.5, "height": 479.4},"caption": ""}`,
+			expectedResult: `<img src="https://www.w3schools.com/html/pic_trulli.jpg" alt="" width="641" height="479" />`},
 	}
 
 	for _, td := range testData {
@@ -370,10 +379,13 @@ func Test_ImageHandler_GenerateMarkdown(t *testing.T) {
 			expectedResult: `<img src="https://www.w3schools.com/html/pic_trulli.jpg" alt="" class="image-tool--withBackground"/>`},
 		// No classes or caption
 		{data: `{"file":{"url": "https://www.w3schools.com/html/pic_trulli.jpg"},"caption": "","withBorder": false,"stretched": false,"withBackground": false}`,
-			expectedResult: `![alt text](https://www.w3schools.com/html/pic_trulli.jpg "")`},
+			expectedResult: `![](https://www.w3schools.com/html/pic_trulli.jpg)`},
 		// No classes
 		{data: `{"file":{"url": "https://www.w3schools.com/html/pic_trulli.jpg"},"caption": "Some caption","withBorder": false,"stretched": false,"withBackground": false}`,
-			expectedResult: `![alt text](https://www.w3schools.com/html/pic_trulli.jpg "Some caption")`},
+			expectedResult: `![Some caption](https://www.w3schools.com/html/pic_trulli.jpg)`},
+		// Caption with inline html
+		{data: `{"file":{"url": "https://www.w3schools.com/html/pic_trulli.jpg"},"caption": "A <i>[trulli]</i>"}`,
+			expectedResult: `![A \[trulli\]](https://www.w3schools.com/html/pic_trulli.jpg)`},
 	}
 
 	for _, td := range testData {
@@ -384,6 +396,42 @@ func Test_ImageHandler_GenerateMarkdown(t *testing.T) {
 	}
 }
 
+func Test_ImageHandler_GenerateHTML_Options(t *testing.T) {
+	h := &goeditorjs.ImageHandler{Options: &goeditorjs.ImageHandlerOptions{
+		StretchClass: "stretched",
+		Figure:       true,
+		Resizer: func(url string, width int) string {
+			return fmt.Sprintf("%s?w=%d", url, width)
+		},
+		SrcSetWidths: []int{480, 960},
+		Sizes:        "100vw",
+		Loading:      "lazy",
+		Decoding:     "async",
+	}}
+
+	result, err := h.GenerateHTML(goeditorjs.EditorJSBlock{Type: "image", Data: []byte(`{"file":{"url": "/a.jpg"},"caption": "A <b>cat</b>","stretched": true}`)})
+	require.NoError(t, err)
+	require.Equal(t, `<figure><img src="/a.jpg" alt="A cat" srcset="/a.jpg?w=480 480w, /a.jpg?w=960 960w" sizes="100vw" `+
+		`loading="lazy" decoding="async" class="stretched"/><figcaption>A <b>cat</b></figcaption></figure>`, result)
+
+	result, err = h.GenerateHTML(goeditorjs.EditorJSBlock{Type: "image", Data: []byte(`{"file":{"url": "/a.jpg"},"caption": ""}`)})
+	require.NoError(t, err)
+	require.Equal(t, `<figure><img src="/a.jpg" alt="" srcset="/a.jpg?w=480 480w, /a.jpg?w=960 960w" sizes="100vw" `+
+		`loading="lazy" decoding="async" /></figure>`, result)
+}
+
+func Test_SimpleImageHandler_Type(t *testing.T) {
+	h := &goeditorjs.SimpleImageHandler{}
+	require.Equal(t, "simpleImage", h.Type())
+}
+
+func Test_SimpleImageHandler_GenerateMarkdown(t *testing.T) {
+	h := &goeditorjs.SimpleImageHandler{}
+	result, err := h.GenerateMarkdown(goeditorjs.EditorJSBlock{Type: "simpleImage", Data: []byte(`{"url": "/a.jpg","caption": "A cat"}`)})
+	require.NoError(t, err)
+	require.Equal(t, `![A cat](/a.jpg)`, result)
+}
+
 func Test_TableHandler_GenerateMarkdown(t *testing.T) {
 	h := &goeditorjs.TableHandler{}
 
@@ -394,3 +442,66 @@ func Test_TableHandler_GenerateMarkdown(t *testing.T) {
 
 	require.Equal(t, result, "| title | subtitle |  |\n| --- | --- | --- |\n| 123 | 111 |  |\n| 333 | 2222 |  |\n")
 }
+
+func Test_TableHandler_GenerateHTML(t *testing.T) {
+	h := &goeditorjs.TableHandler{}
+
+	result, err := h.GenerateHTML(goeditorjs.EditorJSBlock{Type: "table", Data: json.RawMessage(`{"withHeadings":true,"content":[["title","subtitle"],["123","111"]]}`)})
+	require.NoError(t, err)
+	require.Equal(t, "<table><tr><th>title</th><th>subtitle</th></tr><tr><td>123</td><td>111</td></tr></table>", result)
+}
+
+func Test_QuoteHandler_Type(t *testing.T) {
+	h := &goeditorjs.QuoteHandler{}
+	require.Equal(t, "quote", h.Type())
+}
+
+func Test_QuoteHandler_GenerateHTML(t *testing.T) {
+	h := &goeditorjs.QuoteHandler{}
+	testData := []struct {
+		data           string
+		expectedResult string
+	}{
+		{data: `{"text": "To be", "caption": "Hamlet", "alignment": "left"}`,
+			expectedResult: "<blockquote>To be<cite>Hamlet</cite></blockquote>"},
+		{data: `{"text": "To be", "caption": "", "alignment": "center"}`,
+			expectedResult: `<blockquote style="text-align:center">To be</blockquote>`},
+	}
+
+	for _, td := range testData {
+		result, err := h.GenerateHTML(goeditorjs.EditorJSBlock{Type: "quote", Data: []byte(td.data)})
+		require.NoError(t, err)
+		require.Equal(t, td.expectedResult, result)
+	}
+}
+
+func Test_QuoteHandler_GenerateMarkdown(t *testing.T) {
+	h := &goeditorjs.QuoteHandler{}
+	result, err := h.GenerateMarkdown(goeditorjs.EditorJSBlock{Type: "quote", Data: []byte(`{"text": "To be<br>or not", "caption": "Hamlet"}`)})
+	require.NoError(t, err)
+	require.Equal(t, "> To be\\\n> or not\n>\n> — Hamlet", result)
+}
+
+func Test_QuoteHandler_Returns_Parse_Err(t *testing.T) {
+	h := &goeditorjs.QuoteHandler{}
+	_, err := h.GenerateHTML(goeditorjs.EditorJSBlock{Type: "quote", Data: []byte{}})
+	require.Error(t, err)
+	_, err = h.GenerateMarkdown(goeditorjs.EditorJSBlock{Type: "quote", Data: []byte{}})
+	require.Error(t, err)
+}
+
+func Test_CodeBoxHandler_GenerateMarkdown_Entities_And_Fence(t *testing.T) {
+	h := &goeditorjs.CodeBoxHandler{}
+	jsonData := []byte("{\"language\": \"md\", \"code\": \"a &lt; b<div>```go</div><div>&amp;nbsp;</div>\"}")
+	md, err := h.GenerateMarkdown(goeditorjs.EditorJSBlock{Type: "codeBox", Data: jsonData})
+	require.NoError(t, err)
+	require.Equal(t, "````markdown\na < b\n```go\n&nbsp;\n````", md)
+}
+
+func Test_CodeHandler_GenerateMarkdown_Plain_Text(t *testing.T) {
+	h := &goeditorjs.CodeHandler{}
+	jsonData := []byte(`{"language": "html", "code": "<div>&amp;</div>"}`)
+	md, err := h.GenerateMarkdown(goeditorjs.EditorJSBlock{Type: "code", Data: jsonData})
+	require.NoError(t, err)
+	require.Equal(t, "```html\n<div>&amp;</div>\n```", md)
+}
@@ -0,0 +1,356 @@
+package goeditorjs
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Highlighter highlights code for HTML output
+type Highlighter interface {
+	// Highlight returns code of the normalized language as HTML. Unlike code itself, the result is inserted into the
+	// output as is, so it must be escaped by the Highlighter.
+	Highlight(code, language string) (string, error)
+}
+
+// HighlighterFunc adapts a function, e.g. a wrapper around a lexer library, to a Highlighter
+type HighlighterFunc func(code, language string) (string, error)
+
+// Highlight calls f(code, language)
+func (f HighlighterFunc) Highlight(code, language string) (string, error) {
+	return f(code, language)
+}
+
+// languageAliases maps common language names to the name used in classes and markdown fences
+var languageAliases = map[string]string{
+	"js":         "javascript",
+	"jsx":        "javascript",
+	"node":       "javascript",
+	"ts":         "typescript",
+	"tsx":        "typescript",
+	"py":         "python",
+	"python3":    "python",
+	"golang":     "go",
+	"sh":         "bash",
+	"shell":      "bash",
+	"zsh":        "bash",
+	"console":    "bash",
+	"yml":        "yaml",
+	"rb":         "ruby",
+	"rs":         "rust",
+	"kt":         "kotlin",
+	"cs":         "csharp",
+	"c#":         "csharp",
+	"c++":        "cpp",
+	"cc":         "cpp",
+	"cxx":        "cpp",
+	"h":          "c",
+	"md":         "markdown",
+	"htm":        "html",
+	"xhtml":      "html",
+	"ps1":        "powershell",
+	"postgres":   "sql",
+	"postgresql": "sql",
+	"mysql":      "sql",
+	"dockerfile": "docker",
+	"txt":        "plaintext",
+	"text":       "plaintext",
+	"plain":      "plaintext",
+	"mmd":        "mermaid",
+	"puml":       "plantuml",
+	"dot":        "graphviz",
+	"gv":         "graphviz",
+}
+
+// NormalizeLanguage lowercases language and resolves common aliases, e.g. "js" to "javascript"
+func NormalizeLanguage(language string) string {
+	language = strings.ToLower(strings.TrimSpace(language))
+	if alias, ok := languageAliases[language]; ok {
+		return alias
+	}
+	return language
+}
+
+var codeInfoLinesRegexp = regexp.MustCompile(`\{([0-9,\s-]*)\}\s*$`)
+
+// lineRanges are ranges of line numbers, each holding its first and last line
+type lineRanges [][2]int
+
+// contains returns true if line is in one of the ranges
+func (r lineRanges) contains(line int) bool {
+	for _, bounds := range r {
+		if line >= bounds[0] && line <= bounds[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// parseCodeInfo splits a code block language of the form "js {1,3-5}" into the normalized language and the
+// highlighted line ranges
+func parseCodeInfo(info string) (string, lineRanges) {
+	lines := lineRanges{}
+	match := codeInfoLinesRegexp.FindStringSubmatchIndex(info)
+	if match == nil {
+		return NormalizeLanguage(info), lines
+	}
+
+	for _, part := range strings.Split(info[match[2]:match[3]], ",") {
+		bounds := strings.SplitN(strings.TrimSpace(part), "-", 2)
+		from, err := strconv.Atoi(bounds[0])
+		if err != nil {
+			continue
+		}
+		to := from
+		if len(bounds) == 2 {
+			if to, err = strconv.Atoi(bounds[1]); err != nil {
+				continue
+			}
+		}
+		if from <= to {
+			lines = append(lines, [2]int{from, to})
+		}
+	}
+	return NormalizeLanguage(info[:match[0]]), lines
+}
+
+// codeInfoString returns the markdown fence info string of a code block language
+func codeInfoString(info string) string {
+	language, _ := parseCodeInfo(info)
+	if diagramLanguages[language] {
+		// diagram fences are only recognized without further info
+		return language
+	}
+	if match := codeInfoLinesRegexp.FindString(info); match != "" {
+		return strings.TrimSpace(language + " " + strings.TrimSpace(match))
+	}
+	return language
+}
+
+// escapeCode escapes code for use as element content
+func escapeCode(code string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(code)
+}
+
+// highlightLines wraps every line of the highlighted html into a line span, closing and reopening the elements
+// that span multiple lines. Line numbers are added if lineNumbers is set.
+func highlightLines(html string, lineNumbers bool, highlighted lineRanges) string {
+	open := []string{}
+	lines := strings.Split(html, "\n")
+	for i, line := range lines {
+		sb := strings.Builder{}
+		class := "line"
+		if highlighted.contains(i + 1) {
+			class += " highlighted"
+		}
+		sb.WriteString(fmt.Sprintf(`<span class="%s">`, class))
+		if lineNumbers {
+			sb.WriteString(fmt.Sprintf(`<span class="line-number">%d</span>`, i+1))
+		}
+
+		// reopen the elements left open by the previous line
+		sb.WriteString(strings.Join(open, ""))
+		sb.WriteString(line)
+		for _, tag := range htmlTagRegexp.FindAllString(line, -1) {
+			switch {
+			case strings.HasPrefix(tag, "</"):
+				if len(open) > 0 {
+					open = open[:len(open)-1]
+				}
+			case !strings.HasSuffix(tag, "/>"):
+				open = append(open, tag)
+			}
+		}
+		for j := len(open) - 1; j >= 0; j-- {
+			sb.WriteString("</" + htmlTagRegexp.FindStringSubmatch(open[j])[1] + ">")
+		}
+
+		sb.WriteString("</span>")
+		lines[i] = sb.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+var htmlTagRegexp = regexp.MustCompile(`</?([a-zA-Z][a-zA-Z0-9-]*)[^>]*>`)
+
+// BuiltinHighlighter is a dependency free Highlighter for common languages. It wraps keywords, strings, comments and
+// numbers into spans with the classes "hl-keyword", "hl-string", "hl-comment" and "hl-number".
+// Languages it doesn't know are escaped only.
+type BuiltinHighlighter struct{}
+
+// lexer describes the tokens of a language for the BuiltinHighlighter
+type lexer struct {
+	keywords      map[string]bool
+	lineComments  []string
+	blockComments [][2]string
+	quotes        string
+}
+
+func newLexer(keywords string, lineComments []string, blockComments [][2]string, quotes string) *lexer {
+	l := &lexer{keywords: map[string]bool{}, lineComments: lineComments, blockComments: blockComments, quotes: quotes}
+	for _, k := range strings.Fields(keywords) {
+		l.keywords[k] = true
+	}
+	return l
+}
+
+var (
+	cComments = [][2]string{{"/*", "*/"}}
+	lexers    = map[string]*lexer{
+		"go": newLexer(`break case chan const continue default defer else fallthrough for func go goto if import
+			interface map package range return select struct switch type var true false nil iota`,
+			[]string{"//"}, cComments, "\"'`"),
+		"javascript": newLexer(`async await break case catch class const continue debugger default delete do else
+			export extends finally for from function if import in instanceof let new of return super switch this throw
+			try typeof var void while with yield true false null undefined`,
+			[]string{"//"}, cComments, "\"'`"),
+		"typescript": newLexer(`abstract any as async await boolean break case catch class const continue declare
+			default delete do else enum export extends finally for from function if implements import in instanceof
+			interface keyof let module namespace never new number of private protected public readonly return string
+			super switch this throw try type typeof unknown var void while yield true false null undefined`,
+			[]string{"//"}, cComments, "\"'`"),
+		"python": newLexer(`and as assert async await break class continue def del elif else except finally for
+			from global if import in is lambda nonlocal not or pass raise return try while with yield True False None`,
+			[]string{"#"}, nil, "\"'"),
+		"bash": newLexer(`if then else elif fi case esac for while until do done in function return local export
+			echo exit`, []string{"#"}, nil, "\"'"),
+		"java": newLexer(`abstract boolean break byte case catch char class const continue default do double else
+			enum extends final finally float for if implements import instanceof int interface long new package
+			private protected public return short static super switch this throw throws try void while true false null`,
+			[]string{"//"}, cComments, "\"'"),
+		"c": newLexer(`auto break case char const continue default do double else enum extern float for goto if int
+			long register return short signed sizeof static struct switch typedef union unsigned void volatile while
+			NULL`, []string{"//"}, cComments, "\"'"),
+		"cpp": newLexer(`auto bool break case catch char class const constexpr continue default delete do double else
+			enum explicit extern false float for friend if inline int long namespace new nullptr operator private
+			protected public return short signed sizeof static struct switch template this throw true try typedef
+			typename union unsigned using virtual void while`, []string{"//"}, cComments, "\"'"),
+		"csharp": newLexer(`abstract as base bool break case catch char class const continue decimal default
+			delegate do double else enum event false finally float for foreach if in int interface internal is
+			namespace new null object out override private protected public readonly ref return sealed static string
+			struct switch this throw true try using var virtual void while`, []string{"//"}, cComments, "\"'"),
+		"rust": newLexer(`as async await break const continue crate else enum extern false fn for if impl in let loop
+			match mod move mut pub ref return self Self static struct super trait true type unsafe use where while`,
+			[]string{"//"}, cComments, "\""),
+		"ruby": newLexer(`alias and begin break case class def defined do else elsif end ensure false for if in
+			module next nil not or redo rescue retry return self super then true undef unless until when while yield`,
+			[]string{"#"}, nil, "\"'"),
+		"php": newLexer(`abstract and array as break case catch class clone const continue declare default do echo
+			else elseif empty extends final finally fn for foreach function global if implements include interface
+			isset namespace new null or private protected public require return static switch throw trait try use var
+			while true false`, []string{"//", "#"}, cComments, "\"'"),
+		"sql": newLexer(`select from where and or not insert into values update set delete create table drop alter
+			index join left right inner outer on group by order having limit offset as distinct null is in like
+			between union all primary key foreign references default SELECT FROM WHERE AND OR NOT INSERT INTO VALUES
+			UPDATE SET DELETE CREATE TABLE DROP ALTER INDEX JOIN LEFT RIGHT INNER OUTER ON GROUP BY ORDER HAVING LIMIT
+			OFFSET AS DISTINCT NULL IS IN LIKE BETWEEN UNION ALL PRIMARY KEY FOREIGN REFERENCES DEFAULT`,
+			[]string{"--"}, cComments, "'\""),
+		"json": newLexer(`true false null`, nil, nil, "\""),
+		"yaml": newLexer(`true false null yes no on off`, []string{"#"}, nil, "\"'"),
+		"css":  newLexer(`important`, nil, cComments, "\"'"),
+	}
+)
+
+// Highlight highlights code of language
+func (*BuiltinHighlighter) Highlight(code, language string) (string, error) {
+	l, ok := lexers[NormalizeLanguage(language)]
+	if !ok {
+		return escapeCode(code), nil
+	}
+	return l.highlight(code), nil
+}
+
+func (l *lexer) highlight(code string) string {
+	sb := strings.Builder{}
+	token := func(class, text string) {
+		sb.WriteString(fmt.Sprintf(`<span class="hl-%s">%s</span>`, class, escapeCode(text)))
+	}
+
+	for i := 0; i < len(code); {
+		rest := code[i:]
+		if n := l.comment(rest); n > 0 {
+			token("comment", rest[:n])
+			i += n
+			continue
+		}
+
+		c, size := utf8.DecodeRuneInString(rest)
+		switch {
+		case strings.ContainsRune(l.quotes, c):
+			n := stringLength(rest)
+			token("string", rest[:n])
+			i += n
+		case unicode.IsDigit(c):
+			n := strings.IndexFunc(rest, func(r rune) bool {
+				return !unicode.IsDigit(r) && !unicode.IsLetter(r) && r != '.' && r != '_'
+			})
+			if n < 0 {
+				n = len(rest)
+			}
+			token("number", rest[:n])
+			i += n
+		case c == '_' || unicode.IsLetter(c):
+			n := strings.IndexFunc(rest, func(r rune) bool {
+				return r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r)
+			})
+			if n < 0 {
+				n = len(rest)
+			}
+			if l.keywords[rest[:n]] {
+				token("keyword", rest[:n])
+			} else {
+				sb.WriteString(escapeCode(rest[:n]))
+			}
+			i += n
+		default:
+			sb.WriteString(escapeCode(rest[:size]))
+			i += size
+		}
+	}
+	return sb.String()
+}
+
+// comment returns the length of the comment at the start of code, or 0 if it doesn't start with a comment
+func (l *lexer) comment(code string) int {
+	for _, lc := range l.lineComments {
+		if strings.HasPrefix(code, lc) {
+			if n := strings.IndexByte(code, '\n'); n >= 0 {
+				return n
+			}
+			return len(code)
+		}
+	}
+	for _, bc := range l.blockComments {
+		if strings.HasPrefix(code, bc[0]) {
+			if n := strings.Index(code[len(bc[0]):], bc[1]); n >= 0 {
+				return len(bc[0]) + n + len(bc[1])
+			}
+			return len(code)
+		}
+	}
+	return 0
+}
+
+// stringLength returns the length of the string literal at the start of code, honoring backslash escapes.
+// Only backtick strings may span multiple lines.
+func stringLength(code string) int {
+	quote := code[0]
+	for i := 1; i < len(code); i++ {
+		switch code[i] {
+		case '\\':
+			if quote != '`' {
+				i++
+			}
+		case '\n':
+			if quote != '`' {
+				return i
+			}
+		case quote:
+			return i + 1
+		}
+	}
+	return len(code)
+}
@@ -0,0 +1,90 @@
+package goeditorjs_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/davidscottmills/goeditorjs"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NormalizeLanguage(t *testing.T) {
+	require.Equal(t, "javascript", goeditorjs.NormalizeLanguage(" JS "))
+	require.Equal(t, "cpp", goeditorjs.NormalizeLanguage("c++"))
+	require.Equal(t, "go", goeditorjs.NormalizeLanguage("go"))
+	require.Equal(t, "unknown", goeditorjs.NormalizeLanguage("Unknown"))
+}
+
+func Test_BuiltinHighlighter_Highlight(t *testing.T) {
+	h := &goeditorjs.BuiltinHighlighter{}
+	testData := []struct {
+		language       string
+		code           string
+		expectedResult string
+	}{
+		{language: "go", code: `func f() { return "a<b" } // done`,
+			expectedResult: `<span class="hl-keyword">func</span> f() { <span class="hl-keyword">return</span> <span class="hl-string">"a&lt;b"</span> } <span class="hl-comment">// done</span>`},
+		{language: "py", code: `x = 42 # answer`,
+			expectedResult: `x = <span class="hl-number">42</span> <span class="hl-comment"># answer</span>`},
+		{language: "js", code: `let s = 'it\'s'`,
+			expectedResult: `<span class="hl-keyword">let</span> s = <span class="hl-string">'it\'s'</span>`},
+		{language: "brainfuck", code: `<+>`, expectedResult: `&lt;+&gt;`},
+		{language: "go", code: `y → z`, expectedResult: `y → z`},
+		{language: "go", code: `s := "שלום" // שלום`,
+			expectedResult: `s := <span class="hl-string">"שלום"</span> <span class="hl-comment">// שלום</span>`},
+		{language: "python", code: `π = 3.14 ✓`, expectedResult: `π = <span class="hl-number">3.14</span> ✓`},
+	}
+
+	for _, td := range testData {
+		result, err := h.Highlight(td.code, td.language)
+		require.NoError(t, err)
+		require.Equal(t, td.expectedResult, result)
+	}
+}
+
+func Test_CodeBoxHandler_GenerateHTML_Escapes(t *testing.T) {
+	h := &goeditorjs.CodeHandler{}
+	result, err := h.GenerateHTML(goeditorjs.EditorJSBlock{Type: "code", Data: []byte(`{"language": "js", "code": "if (a < b && c) {}"}`)})
+	require.NoError(t, err)
+	require.Equal(t, `<pre><code class="javascript">if (a &lt; b &amp;&amp; c) {}</code></pre>`, result)
+}
+
+func Test_CodeBoxHandler_GenerateHTML_Highlighted(t *testing.T) {
+	h := &goeditorjs.CodeHandler{CodeBoxHandler: goeditorjs.CodeBoxHandler{Options: &goeditorjs.CodeBoxHandlerOptions{
+		Highlighter: &goeditorjs.BuiltinHighlighter{},
+		LineNumbers: true,
+	}}}
+	result, err := h.GenerateHTML(goeditorjs.EditorJSBlock{Type: "code", Data: []byte(`{"language": "go {2}", "code": "/* a\nb */\nx := 1"}`)})
+	require.NoError(t, err)
+	require.Equal(t, `<pre><code class="go">`+
+		`<span class="line"><span class="line-number">1</span><span class="hl-comment">/* a</span></span>`+"\n"+
+		`<span class="line highlighted"><span class="line-number">2</span><span class="hl-comment">b */</span></span>`+"\n"+
+		`<span class="line"><span class="line-number">3</span>x := <span class="hl-number">1</span></span>`+
+		`</code></pre>`, result)
+}
+
+func Test_CodeBoxHandler_GenerateHTML_Highlighted_Large_Range(t *testing.T) {
+	h := &goeditorjs.CodeHandler{}
+	result, err := h.GenerateHTML(goeditorjs.EditorJSBlock{Type: "code", Data: []byte(`{"language": "go {2-2000000000}", "code": "a\nb"}`)})
+	require.NoError(t, err)
+	require.Equal(t, `<pre><code class="go">`+
+		`<span class="line">a</span>`+"\n"+
+		`<span class="line highlighted">b</span>`+
+		`</code></pre>`, result)
+}
+
+func Test_CodeBoxHandler_GenerateHTML_Highlighter_Err(t *testing.T) {
+	mockErr := errors.New("Mock Error")
+	h := &goeditorjs.CodeBoxHandler{Options: &goeditorjs.CodeBoxHandlerOptions{
+		Highlighter: goeditorjs.HighlighterFunc(func(code, language string) (string, error) { return "", mockErr }),
+	}}
+	_, err := h.GenerateHTML(goeditorjs.EditorJSBlock{Type: "codeBox", Data: []byte(`{"language": "go", "code": "x"}`)})
+	require.Equal(t, mockErr, err)
+}
+
+func Test_CodeBoxHandler_GenerateMarkdown_Normalizes_Language(t *testing.T) {
+	h := &goeditorjs.CodeBoxHandler{}
+	result, err := h.GenerateMarkdown(goeditorjs.EditorJSBlock{Type: "codeBox", Data: []byte(`{"language": "JS {1,3-5}", "code": "x"}`)})
+	require.NoError(t, err)
+	require.Equal(t, "```javascript {1,3-5}\nx\n```", result)
+}
@@ -9,6 +9,23 @@ import (
 // HTMLEngine is the engine that creates the HTML from EditorJS blocks
 type HTMLEngine struct {
 	BlockHandlers map[string]HTMLBlockHandler
+	// Document makes the engine render complete HTML documents instead of fragments if set
+	Document *HTMLDocumentOptions
+	// ClassMap holds the classes the built-in handlers emit, unless overridden by the handler's own classes
+	ClassMap ClassMap
+	// MathRenderer renders the inline math of all blocks and the math blocks whose handler has no renderer.
+	// If nil, formulas are wrapped in KaTeX/MathJax delimiters for client side rendering.
+	MathRenderer MathRenderer
+	// DiagramRenderer renders the diagrams of mermaid blocks and of code blocks in a diagram language.
+	// If nil, diagrams are rendered as <pre class="mermaid"> (or "plantuml", "graphviz") for client side rendering.
+	DiagramRenderer DiagramRenderer
+	// Transformers transform the blocks of every document before it is rendered, see WithHTMLTransformers
+	Transformers []Transformer
+	// footnotes collects the footnotes of the document being generated
+	footnotes *footnoteCollector
+	// unknown makes the engine render blocks without handler or whose handler fails by unknownHTMLBlockHandler, including the
+	// blocks of nested documents
+	unknown bool
 }
 
 // HTMLBlockHandler is an interface for a plugable EditorJS HTML generator
@@ -17,10 +34,42 @@ type HTMLBlockHandler interface {
 	GenerateHTML(editorJSBlock EditorJSBlock) (string, error)
 }
 
+// HTMLEngineBlockHandler is implemented by HTMLBlockHandlers that need the engine generating them, e.g. to read
+// engine wide options like the ClassMap. The engine calls GenerateHTMLWithEngine instead of GenerateHTML.
+type HTMLEngineBlockHandler interface {
+	HTMLBlockHandler
+	GenerateHTMLWithEngine(editorJSBlock EditorJSBlock, engine *HTMLEngine) (string, error)
+}
+
+// BlockOwner is implemented by handlers of container blocks that own the blocks following them in the document, like
+// the items of a toggle block. The engines render the owned blocks as children of the container instead of at top
+// level.
+type BlockOwner interface {
+	// OwnedBlockCount returns how many of the blocks following block are owned by it, e.g. a count stored in its data
+	// or the run of following blocks whose ids are listed in its data. The range includes the blocks owned by nested
+	// owners.
+	OwnedBlockCount(editorJSBlock EditorJSBlock, following []EditorJSBlock) int
+}
+
+// HTMLBlockOwnerHandler is implemented by HTMLBlockHandlers of container blocks owning the blocks following them.
+// The engine calls GenerateHTMLWithChildren with the html of the owned blocks instead of GenerateHTML.
+type HTMLBlockOwnerHandler interface {
+	HTMLBlockHandler
+	BlockOwner
+	GenerateHTMLWithChildren(editorJSBlock EditorJSBlock, children string, engine *HTMLEngine) (string, error)
+}
+
+// HTMLEngineOptions configure the HTMLEngine
+type HTMLEngineOptions func(h *HTMLEngine)
+
 // NewHTMLEngine creates a new HTMLEngine
-func NewHTMLEngine() *HTMLEngine {
+func NewHTMLEngine(opts ...HTMLEngineOptions) *HTMLEngine {
 	bhs := make(map[string]HTMLBlockHandler)
-	return &HTMLEngine{BlockHandlers: bhs}
+	h := &HTMLEngine{BlockHandlers: bhs}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
 }
 
 // RegisterBlockHandlers registers or overrides a block handlers for blockType given by HTMLBlockHandler.Type()
@@ -32,24 +81,34 @@ func (htmlEngine *HTMLEngine) RegisterBlockHandlers(handlers ...HTMLBlockHandler
 
 // GenerateHTML generates html from the editorJS using configured set of HTML handlers
 func (htmlEngine *HTMLEngine) GenerateHTML(editorJSData string) (string, error) {
-	result := ""
-	ejs, err := parseEditorJSON(editorJSData)
+	ejs, err := ParseDocument(editorJSData)
 	if err != nil {
 		return "", err
 	}
-	for _, block := range ejs.Blocks {
-		if generator, ok := htmlEngine.BlockHandlers[block.Type]; ok {
-			html, err := generator.GenerateHTML(block)
-			if err != nil {
-				return result, err
-			}
-			result += html
-		} else {
-			return "", fmt.Errorf("%w, Block Type: %s", ErrBlockHandlerNotFound, block.Type)
-		}
+	engine := htmlEngine.document()
+	result, err := engine.GenerateBlocksHTML(ejs.Blocks)
+	if err != nil {
+		return result, err
 	}
 
-	return result, nil
+	return engine.wrap(ejs, result+engine.footnotes.html(engine))
+}
+
+// document returns a copy of the engine to generate a single document with, holding the state of the document
+func (htmlEngine *HTMLEngine) document() *HTMLEngine {
+	engine := *htmlEngine
+	engine.footnotes = newFootnoteCollector()
+	return &engine
+}
+
+// GenerateBlocksHTML generates html from blocks using configured set of HTML handlers. Handlers of container blocks
+// use it through the engine passed to GenerateHTMLWithEngine to render the documents nested in their data.
+func (htmlEngine *HTMLEngine) GenerateBlocksHTML(blocks []EditorJSBlock) (string, error) {
+	blocks, err := Pipeline(htmlEngine.Transformers).Transform(blocks)
+	if err != nil {
+		return "", err
+	}
+	return htmlEngine.generateBlocks(blocks)
 }
 
 func unknownHTMLBlockHandler(data EditorJSBlock) string {
@@ -59,23 +118,110 @@ func unknownHTMLBlockHandler(data EditorJSBlock) string {
 
 // GenerateHTMLWithUnknownBlock generates html from the editorJS using configured set of HTML handlers
 func (htmlEngine *HTMLEngine) GenerateHTMLWithUnknownBlock(editorJSData string) (string, error) {
-	result := strings.Builder{}
-	ejs, err := parseEditorJSON(editorJSData)
+	ejs, err := ParseDocument(editorJSData)
 	if err != nil {
 		return "", err
 	}
-	for _, block := range ejs.Blocks {
-		if generator, ok := htmlEngine.BlockHandlers[block.Type]; ok {
-			html, err := generator.GenerateHTML(block)
-			if err != nil {
+	engine := htmlEngine.document()
+	engine.unknown = true
+	// blocks don't fail in unknown mode, only the transformers do
+	result, err := engine.GenerateBlocksHTML(ejs.Blocks)
+	if err != nil {
+		return "", err
+	}
+
+	return engine.wrap(ejs, result+engine.footnotes.html(engine))
+}
+
+// generateBlocks generates the html of blocks. If the engine is in unknown mode, blocks without handler or whose
+// handler fails are rendered by unknownHTMLBlockHandler instead of returning an error.
+func (htmlEngine *HTMLEngine) generateBlocks(blocks []EditorJSBlock) (string, error) {
+	result := strings.Builder{}
+	for i := 0; i < len(blocks); i++ {
+		block := blocks[i]
+		generator, ok := htmlEngine.BlockHandlers[block.Type]
+		if !ok {
+			if htmlEngine.unknown {
+				result.WriteString(unknownHTMLBlockHandler(block))
+				continue
+			}
+			return "", fmt.Errorf("%w, Block Type: %s", ErrBlockHandlerNotFound, block.Type)
+		}
+
+		html, owned, err := htmlEngine.generateBlock(generator, blocks[i:])
+		if err != nil {
+			if htmlEngine.unknown {
 				result.WriteString(unknownHTMLBlockHandler(block))
+				// the blocks owned by the failing block are part of it
+				i += owned
 				continue
 			}
-			result.WriteString(html)
-		} else {
-			result.WriteString(unknownHTMLBlockHandler(block))
+			return result.String(), err
 		}
+		result.WriteString(html)
+		i += owned
 	}
 
 	return result.String(), nil
 }
+
+// generateBlock generates the html of the first of blocks with its handler. It returns the number of following blocks
+// the handler owns, which are rendered as its children.
+func (htmlEngine *HTMLEngine) generateBlock(handler HTMLBlockHandler, blocks []EditorJSBlock) (string, int, error) {
+	block, owned := blocks[0], 0
+	if htmlEngine.footnotes != nil {
+		htmlEngine.footnotes.reserve(block)
+	}
+	var children string
+	if h, ok := handler.(HTMLBlockOwnerHandler); ok {
+		owned = ownedBlockCount(h, block, blocks[1:])
+		var err error
+		if children, err = htmlEngine.generateBlocks(blocks[1 : 1+owned]); err != nil {
+			return "", owned, err
+		}
+	}
+	html, err := htmlEngine.renderBlock(handler, block, children)
+	return html, owned, err
+}
+
+// renderBlock generates the html of block with its handler, the html of its children is passed to owner handlers.
+// The footnotes of block must have been reserved.
+func (htmlEngine *HTMLEngine) renderBlock(handler HTMLBlockHandler, block EditorJSBlock, children string) (string, error) {
+	var html string
+	var err error
+	switch h := handler.(type) {
+	case HTMLBlockOwnerHandler:
+		html, err = h.GenerateHTMLWithChildren(block, children, htmlEngine)
+	case HTMLEngineBlockHandler:
+		html, err = h.GenerateHTMLWithEngine(block, htmlEngine)
+	default:
+		html, err = handler.GenerateHTML(block)
+	}
+	if err != nil {
+		return "", err
+	}
+	if htmlEngine.footnotes != nil {
+		html = htmlEngine.footnotes.collect(block, html, htmlFootnoteRef(htmlEngine))
+	}
+	return renderInlineMath(html, htmlEngine.MathRenderer, htmlEngine)
+}
+
+// wrap wraps the generated html into a complete document if the engine is configured to do so
+func (htmlEngine *HTMLEngine) wrap(ejs *Document, html string) (string, error) {
+	if htmlEngine.Document == nil {
+		return html, nil
+	}
+	return wrapDocument(htmlEngine.Document, ejs, html)
+}
+
+// ownedBlockCount returns the number of following blocks owned by block, limited to the available blocks
+func ownedBlockCount(owner BlockOwner, block EditorJSBlock, following []EditorJSBlock) int {
+	count := owner.OwnedBlockCount(block, following)
+	if count < 0 {
+		return 0
+	}
+	if count > len(following) {
+		return len(following)
+	}
+	return count
+}
@@ -0,0 +1,149 @@
+package goeditorjs
+
+import (
+	"html/template"
+	"strings"
+)
+
+// HTMLDocumentOptions are the options used by the HTMLEngine to render complete HTML documents instead of fragments
+type HTMLDocumentOptions struct {
+	// Template renders the document and is executed with an HTMLDocument.
+	// If not provided, DefaultHTMLDocumentTemplate will be used.
+	Template *template.Template
+	// Title overrides the title taken from the first header block
+	Title string
+	// Lang is the value of the lang attribute of the html element
+	Lang string
+	// Stylesheets are the urls of the stylesheets linked in the head
+	Stylesheets []string
+	// DefaultCSS embeds DefaultHTMLDocumentCSS in the head
+	DefaultCSS bool
+}
+
+// HTMLDocument is the data the document template is executed with
+type HTMLDocument struct {
+	// Title is the plain text of the first header block
+	Title string
+	// Description is the plain text of the first paragraph block
+	Description string
+	Lang        string
+	Stylesheets []string
+	CSS         template.CSS
+	// Body is the HTML generated by the block handlers
+	Body template.HTML
+}
+
+// DefaultHTMLDocumentTemplate is the template used when HTMLDocumentOptions.Template is not provided
+var DefaultHTMLDocumentTemplate = template.Must(template.New("document").Parse(`<!DOCTYPE html>
+<html{{with .Lang}} lang="{{.}}"{{end}}>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+{{- with .Description}}
+<meta name="description" content="{{.}}">
+{{- end}}
+{{- range .Stylesheets}}
+<link rel="stylesheet" href="{{.}}">
+{{- end}}
+{{- with .CSS}}
+<style>{{.}}</style>
+{{- end}}
+</head>
+<body>
+{{.Body}}
+</body>
+</html>
+`))
+
+// DefaultHTMLDocumentCSS styles the markup and the default classes emitted by the built-in handlers
+const DefaultHTMLDocumentCSS = `body{max-width:720px;margin:0 auto;padding:2rem 1rem;font-family:-apple-system,BlinkMacSystemFont,"Segoe UI",Roboto,sans-serif;line-height:1.6;color:#1d202b}
+img,video{max-width:100%;height:auto}
+audio{width:100%}
+pre{overflow-x:auto;padding:1rem;background:#f6f8fa;border-radius:4px}
+code{font-family:SFMono-Regular,Consolas,"Liberation Mono",Menlo,monospace;font-size:.9em}
+pre .line{display:inline-block;width:100%}
+pre .line.highlighted{background:#fff5b1}
+pre .line-number{display:inline-block;width:2.5em;margin-right:1em;color:#959da5;text-align:right;user-select:none}
+.hl-keyword{color:#d73a49}
+.hl-string{color:#032f62}
+.hl-comment{color:#6a737d;font-style:italic}
+.hl-number{color:#005cc5}
+table{border-collapse:collapse;width:100%}
+td,th{border:1px solid #e8e8eb;padding:.4rem .6rem;text-align:left}
+blockquote{margin:1rem 0;padding-left:1rem;border-left:4px solid #e8e8eb;color:#55595c}
+blockquote cite{display:block;margin-top:.5rem;font-size:.9em}
+hr{margin:2rem 0;border:0;border-top:1px solid #e8e8eb}
+.warning{margin:1rem 0;padding:.75rem 1rem;border-left:4px solid #f0b400;background:#fff8e1}
+.warning__title{margin:0;font-weight:600}
+.warning__message{margin:0}
+.alert{margin:1rem 0;padding:.75rem 1rem;border-radius:4px;background:#e8f1fb}
+.alert--success{background:#e6f6ec}
+.alert--warning{background:#fff8e1}
+.alert--danger{background:#fdecea}
+.link-tool{display:flex;justify-content:space-between;gap:1rem;margin:1rem 0;padding:1rem;border:1px solid #e8e8eb;border-radius:6px;color:inherit;text-decoration:none}
+.link-tool__title{font-weight:600}
+.link-tool__description{margin:.25rem 0;color:#55595c}
+.link-tool__anchor{font-size:.85em;color:#959da5}
+.link-tool__image{width:65px;height:65px;object-fit:cover;border-radius:4px}
+.attaches{display:flex;align-items:center;gap:.75rem;margin:1rem 0;padding:.75rem 1rem;border:1px solid #e8e8eb;border-radius:6px;color:inherit;text-decoration:none}
+.attaches__icon{min-width:2.5rem;padding:.25rem;border-radius:4px;background:#eff2f5;font-size:.75em;font-weight:600;text-align:center}
+.attaches__icon--pdf{background:#fdecea;color:#c0392b}
+.attaches__title{flex:1}
+.attaches__size{font-size:.85em;color:#959da5}
+figure{margin:1.5rem 0}
+figcaption{margin-top:.5rem;font-size:.9em;color:#55595c;text-align:center}
+pre.mermaid,pre.plantuml,pre.graphviz{background:none;text-align:center}
+.columns{display:grid;grid-auto-flow:column;grid-auto-columns:1fr;gap:1.5rem}
+.columns__column>:first-child{margin-top:0}
+details{margin:1rem 0}
+summary{cursor:pointer;font-weight:600}
+.checklist{padding-left:0;list-style:none}
+.checklist__item input{margin-right:.25rem}
+ins{background:#e6ffec;text-decoration:none}
+del{background:#ffebe9}
+ins.diff,del.diff{display:block}
+.diff--modified,.diff--moved{margin-left:-.75rem;padding-left:.5rem;border-left:.25rem solid #d4a72c}
+.diff--moved{border-left-color:#54aeff}
+.footnotes{margin-top:2rem;padding-top:1rem;border-top:1px solid #e8e8eb;font-size:.9em}
+.footnote-backref{text-decoration:none}
+.math{margin:1rem 0;overflow-x:auto;text-align:center}
+.image-tool--withBorder{border:1px solid #e8e8eb}
+.image-tool--withBackground{padding:15px;background:#cdd1e0}
+.image-tool--stretched{display:block;width:100%;max-width:none}
+`
+
+// WithHTMLDocument makes the HTMLEngine render complete HTML documents using the given options
+func WithHTMLDocument(options *HTMLDocumentOptions) HTMLEngineOptions {
+	return func(h *HTMLEngine) {
+		h.Document = options
+	}
+}
+
+// wrapDocument renders body into the document template configured by options
+func wrapDocument(options *HTMLDocumentOptions, ejs *Document, body string) (string, error) {
+	doc := &HTMLDocument{
+		Title:       options.Title,
+		Lang:        options.Lang,
+		Stylesheets: options.Stylesheets,
+		Body:        template.HTML(body),
+	}
+	if options.DefaultCSS {
+		doc.CSS = template.CSS(DefaultHTMLDocumentCSS)
+	}
+
+	if doc.Title == "" {
+		doc.Title = documentTitle(ejs)
+	}
+	doc.Description = documentDescription(ejs)
+
+	tmpl := options.Template
+	if tmpl == nil {
+		tmpl = DefaultHTMLDocumentTemplate
+	}
+	sb := strings.Builder{}
+	if err := tmpl.Execute(&sb, doc); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,61 @@
+package goeditorjs_test
+
+import (
+	"html/template"
+	"testing"
+
+	"github.com/davidscottmills/goeditorjs"
+	"github.com/stretchr/testify/require"
+)
+
+const documentTestData = `{"blocks": [
+	{"type": "paragraph", "data": {"text": "Intro with <b>bold</b> &amp; \"quotes\"", "alignment": "left"}},
+	{"type": "header", "data": {"text": "My <i>Title</i>", "level": 1}},
+	{"type": "paragraph", "data": {"text": "Second", "alignment": "left"}}
+]}`
+
+func Test_GenerateHTML_Document(t *testing.T) {
+	eng := goeditorjs.NewHTMLEngine(goeditorjs.WithHTMLDocument(&goeditorjs.HTMLDocumentOptions{
+		Lang:        "en",
+		Stylesheets: []string{"/style.css"},
+	}))
+	eng.RegisterBlockHandlers(&goeditorjs.HeaderHandler{}, &goeditorjs.ParagraphHandler{})
+	result, err := eng.GenerateHTML(documentTestData)
+	require.NoError(t, err)
+	require.Equal(t, `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>My Title</title>
+<meta name="description" content="Intro with bold &amp; &#34;quotes&#34;">
+<link rel="stylesheet" href="/style.css">
+</head>
+<body>
+<p>Intro with <b>bold</b> &amp; "quotes"</p><h1>My <i>Title</i></h1><p>Second</p>
+</body>
+</html>
+`, result)
+}
+
+func Test_GenerateHTML_Document_DefaultCSS_And_Title(t *testing.T) {
+	eng := goeditorjs.NewHTMLEngine(goeditorjs.WithHTMLDocument(&goeditorjs.HTMLDocumentOptions{
+		Title:      "Override",
+		DefaultCSS: true,
+	}))
+	eng.RegisterBlockHandlers(&goeditorjs.HeaderHandler{}, &goeditorjs.ParagraphHandler{})
+	result, err := eng.GenerateHTMLWithUnknownBlock(documentTestData)
+	require.NoError(t, err)
+	require.Contains(t, result, "<html>")
+	require.Contains(t, result, "<title>Override</title>")
+	require.Contains(t, result, ".image-tool--stretched{")
+}
+
+func Test_GenerateHTML_Document_Custom_Template(t *testing.T) {
+	tmpl := template.Must(template.New("doc").Parse(`<article title="{{.Title}}">{{.Body}}</article>`))
+	eng := goeditorjs.NewHTMLEngine(goeditorjs.WithHTMLDocument(&goeditorjs.HTMLDocumentOptions{Template: tmpl}))
+	eng.RegisterBlockHandlers(&goeditorjs.HeaderHandler{}, &goeditorjs.ParagraphHandler{})
+	result, err := eng.GenerateHTML(`{"blocks": [{"type": "header", "data": {"text": "A & B", "level": 2}}]}`)
+	require.NoError(t, err)
+	require.Equal(t, `<article title="A &amp; B"><h2>A & B</h2></article>`, result)
+}
@@ -0,0 +1,147 @@
+package goeditorjs
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/url"
+	"strings"
+)
+
+// LinkToolHandler is the default LinkToolHandler for EditorJS HTML and markdown generation.
+// The meta data of link blocks is fetched from third party pages, so it is escaped.
+type LinkToolHandler struct {
+	// Options are made available to the GenerateHTML and GenerateMarkdown functions.
+	// If not provided, DefaultLinkToolHandlerOptions will be used.
+	Options *LinkToolHandlerOptions
+	// Classes override the engine's ClassMap for the elements this handler emits. The keys are "link-tool",
+	// "link-tool__content", "link-tool__title", "link-tool__description", "link-tool__anchor" and "link-tool__image".
+	Classes ClassMap
+}
+
+// LinkToolHandlerOptions are the options available to the LinkToolHandler
+type LinkToolHandlerOptions struct {
+	// Rel is the rel attribute of links to external hosts
+	Rel string
+	// InternalHosts are the hosts that don't get the Rel attribute, e.g. "example.com"
+	InternalHosts []string
+	// NewTab opens links to external hosts in a new tab
+	NewTab bool
+	// HideImage omits the preview image from the card
+	HideImage bool
+	// RewriteURL rewrites the preview image url, e.g. StaticDomainRewriter("https://cdn.example.com")
+	RewriteURL URLRewriter
+}
+
+// DefaultLinkToolHandlerOptions are the default options available to the LinkToolHandler
+var DefaultLinkToolHandlerOptions = &LinkToolHandlerOptions{Rel: "noopener nofollow"}
+
+func (h *LinkToolHandler) parse(editorJSBlock EditorJSBlock) (*LinkTool, error) {
+	if h.Options == nil {
+		h.Options = DefaultLinkToolHandlerOptions
+	}
+
+	link := &LinkTool{}
+	if err := json.Unmarshal(editorJSBlock.Data, link); err != nil {
+		return nil, err
+	}
+	link.Meta.Image.URL = h.Options.RewriteURL.rewrite(link.Meta.Image.URL)
+	return link, nil
+}
+
+// Type "linkTool"
+func (*LinkToolHandler) Type() string {
+	return "linkTool"
+}
+
+// GenerateHTML generates html for LinkToolBlocks
+func (h *LinkToolHandler) GenerateHTML(editorJSBlock EditorJSBlock) (string, error) {
+	return h.GenerateHTMLWithEngine(editorJSBlock, nil)
+}
+
+// GenerateHTMLWithEngine generates html for LinkToolBlocks using the engine's ClassMap
+func (h *LinkToolHandler) GenerateHTMLWithEngine(editorJSBlock EditorJSBlock, engine *HTMLEngine) (string, error) {
+	link, err := h.parse(editorJSBlock)
+	if err != nil {
+		return "", err
+	}
+
+	class := func(name string) string {
+		return classAttr(h.Classes, engine, name, name)
+	}
+	title := link.Meta.Title
+	if title == "" {
+		title = link.Link
+	}
+	anchor := link.Meta.SiteName
+	if anchor == "" {
+		anchor = linkHost(link.Link)
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf(`<a%s%s%s>`, class("link-tool"), hrefAttr(link.Link), h.linkAttrs(link.Link)))
+	sb.WriteString(fmt.Sprintf(`<div%s>`, class("link-tool__content")))
+	sb.WriteString(fmt.Sprintf(`<div%s>%s</div>`, class("link-tool__title"), html.EscapeString(title)))
+	if link.Meta.Description != "" {
+		sb.WriteString(fmt.Sprintf(`<p%s>%s</p>`, class("link-tool__description"), html.EscapeString(link.Meta.Description)))
+	}
+	if anchor != "" {
+		sb.WriteString(fmt.Sprintf(`<span%s>%s</span>`, class("link-tool__anchor"), html.EscapeString(anchor)))
+	}
+	sb.WriteString("</div>")
+	if link.Meta.Image.URL != "" && !h.Options.HideImage {
+		sb.WriteString(fmt.Sprintf(`<img%s src="%s" alt="" loading="lazy"/>`, class("link-tool__image"), html.EscapeString(link.Meta.Image.URL)))
+	}
+	sb.WriteString("</a>")
+
+	return sb.String(), nil
+}
+
+// GenerateMarkdown generates markdown for LinkToolBlocks
+func (h *LinkToolHandler) GenerateMarkdown(editorJSBlock EditorJSBlock) (string, error) {
+	link, err := h.parse(editorJSBlock)
+	if err != nil {
+		return "", err
+	}
+
+	title := link.Meta.Title
+	if title == "" {
+		title = link.Link
+	}
+	md := markdownLink(escapeMarkdown(title), link.Link)
+	if description := strings.TrimSpace(link.Meta.Description); description != "" {
+		md += " — " + escapeMarkdown(description)
+	}
+	return md, nil
+}
+
+// linkAttrs returns the rel and target attributes for href according to the options
+func (h *LinkToolHandler) linkAttrs(href string) string {
+	host := linkHost(href)
+	if host == "" || !safeURL(href) {
+		return ""
+	}
+	for _, internal := range h.Options.InternalHosts {
+		if strings.EqualFold(host, internal) {
+			return ""
+		}
+	}
+
+	attrs := ""
+	if h.Options.Rel != "" {
+		attrs += fmt.Sprintf(` rel="%s"`, html.EscapeString(h.Options.Rel))
+	}
+	if h.Options.NewTab {
+		attrs += ` target="_blank"`
+	}
+	return attrs
+}
+
+// linkHost returns the host of an absolute link, or an empty string for relative links
+func linkHost(link string) string {
+	u, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+	return u.Hostname()
+}
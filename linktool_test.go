@@ -0,0 +1,110 @@
+package goeditorjs_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/davidscottmills/goeditorjs"
+	"github.com/stretchr/testify/require"
+)
+
+const linkToolTestData = `{
+	"link": "https://codex.so/editor?a=1&b=2",
+	"meta": {
+		"title": "CodeX <Team>",
+		"site_name": "CodeX",
+		"description": "Club of web-development, design and marketing",
+		"image": {"url": "/upload/logo.png"}
+	}
+}`
+
+func Test_LinkToolHandler_Type(t *testing.T) {
+	h := &goeditorjs.LinkToolHandler{}
+	require.Equal(t, "linkTool", h.Type())
+}
+
+func Test_LinkToolHandler_Returns_Parse_Err(t *testing.T) {
+	h := &goeditorjs.LinkToolHandler{}
+	_, err := h.GenerateHTML(goeditorjs.EditorJSBlock{Type: "linkTool", Data: []byte{}})
+	require.Error(t, err)
+	_, err = h.GenerateMarkdown(goeditorjs.EditorJSBlock{Type: "linkTool", Data: []byte{}})
+	require.Error(t, err)
+}
+
+func Test_LinkToolHandler_GenerateHTML(t *testing.T) {
+	h := &goeditorjs.LinkToolHandler{}
+	result, err := h.GenerateHTML(goeditorjs.EditorJSBlock{Type: "linkTool", Data: []byte(linkToolTestData)})
+	require.NoError(t, err)
+	require.Equal(t, `<a class="link-tool" href="https://codex.so/editor?a=1&amp;b=2" rel="noopener nofollow">`+
+		`<div class="link-tool__content">`+
+		`<div class="link-tool__title">CodeX &lt;Team&gt;</div>`+
+		`<p class="link-tool__description">Club of web-development, design and marketing</p>`+
+		`<span class="link-tool__anchor">CodeX</span>`+
+		`</div>`+
+		`<img class="link-tool__image" src="/upload/logo.png" alt="" loading="lazy"/>`+
+		`</a>`, result)
+}
+
+func Test_LinkToolHandler_GenerateHTML_Options(t *testing.T) {
+	h := &goeditorjs.LinkToolHandler{Options: &goeditorjs.LinkToolHandlerOptions{
+		Rel:        "noopener",
+		NewTab:     true,
+		HideImage:  true,
+		RewriteURL: goeditorjs.StaticDomainRewriter("https://cdn.example.com"),
+	}}
+	result, err := h.GenerateHTML(goeditorjs.EditorJSBlock{Type: "linkTool", Data: []byte(`{"link": "https://example.com/a"}`)})
+	require.NoError(t, err)
+	require.Equal(t, `<a class="link-tool" href="https://example.com/a" rel="noopener" target="_blank">`+
+		`<div class="link-tool__content"><div class="link-tool__title">https://example.com/a</div>`+
+		`<span class="link-tool__anchor">example.com</span></div></a>`, result)
+
+	h.Options.InternalHosts = []string{"example.com"}
+	result, err = h.GenerateHTML(goeditorjs.EditorJSBlock{Type: "linkTool", Data: []byte(`{"link": "https://example.com/a"}`)})
+	require.NoError(t, err)
+	require.Contains(t, result, `<a class="link-tool" href="https://example.com/a">`)
+}
+
+func Test_LinkToolHandler_GenerateHTML_Unsafe_URL(t *testing.T) {
+	h := &goeditorjs.LinkToolHandler{}
+	for _, link := range []string{"javascript:alert(1)", " JavaScript:alert(1)", "java\tscript:alert(1)", "data:text/html,x"} {
+		data, err := json.Marshal(map[string]string{"link": link})
+		require.NoError(t, err)
+		result, err := h.GenerateHTML(goeditorjs.EditorJSBlock{Type: "linkTool", Data: data})
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(result, `<a class="link-tool"><div`), result)
+	}
+
+	for _, link := range []string{"mailto:a@example.com", "/relative:path", "page?x=a:b"} {
+		data, err := json.Marshal(map[string]string{"link": link})
+		require.NoError(t, err)
+		result, err := h.GenerateHTML(goeditorjs.EditorJSBlock{Type: "linkTool", Data: data})
+		require.NoError(t, err)
+		require.Contains(t, result, ` href="`+link+`"`)
+	}
+}
+
+func Test_LinkToolHandler_GenerateMarkdown(t *testing.T) {
+	h := &goeditorjs.LinkToolHandler{}
+	result, err := h.GenerateMarkdown(goeditorjs.EditorJSBlock{Type: "linkTool", Data: []byte(`{"link": "https://codex.so", "meta": {"title": "[CodeX]", "description": "Club"}}`)})
+	require.NoError(t, err)
+	require.Equal(t, `[\[CodeX\]](https://codex.so) — Club`, result)
+}
+
+func Test_LinkToolHandler_GenerateMarkdown_Unsafe_URL(t *testing.T) {
+	h := &goeditorjs.LinkToolHandler{}
+	result, err := h.GenerateMarkdown(goeditorjs.EditorJSBlock{Type: "linkTool", Data: []byte(`{"link": "javascript:alert(1)", "meta": {"title": "*Click*"}}`)})
+	require.NoError(t, err)
+	require.Equal(t, `\*Click\*`, result)
+
+	result, err = h.GenerateMarkdown(goeditorjs.EditorJSBlock{Type: "linkTool", Data: []byte(`{"link": " JavaScript:alert(1)"}`)})
+	require.NoError(t, err)
+	require.Equal(t, ` JavaScript:alert(1)`, result)
+}
+
+func Test_ImageHandler_RewriteURL(t *testing.T) {
+	h := &goeditorjs.ImageHandler{Options: &goeditorjs.ImageHandlerOptions{RewriteURL: goeditorjs.StaticDomainRewriter("https://cdn.example.com")}}
+	result, err := h.GenerateMarkdown(goeditorjs.EditorJSBlock{Type: "image", Data: []byte(`{"file": {"url": "/a.jpg"}, "caption": ""}`)})
+	require.NoError(t, err)
+	require.Equal(t, `![](https://cdn.example.com/a.jpg)`, result)
+}
@@ -0,0 +1,168 @@
+package goeditorjs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Lint rules reported in LintIssue.Rule
+const (
+	LintRuleEmptyParagraph = "empty-paragraph"
+	LintRuleHeadingSkip    = "heading-level-skip"
+	LintRuleImageCaption   = "image-missing-caption"
+	LintRuleBrokenLink     = "broken-link"
+	LintRuleRawHTML        = "raw-html"
+)
+
+// LintIssue describes a content problem found in an EditorJS document
+type LintIssue struct {
+	BlockIndex int    `json:"blockIndex"`
+	BlockID    string `json:"blockId,omitempty"`
+	BlockType  string `json:"blockType"`
+	Rule       string `json:"rule"`
+	Message    string `json:"message"`
+}
+
+func (l LintIssue) String() string {
+	return fmt.Sprintf("block %d (%s): %s: %s", l.BlockIndex, l.BlockType, l.Rule, l.Message)
+}
+
+// LintOptions are the options available to Lint
+type LintOptions struct {
+	// BaseDir is the directory relative links are resolved against. Relative links are only checked if it is set.
+	BaseDir string
+	// DisabledRules are the rules that won't be reported
+	DisabledRules []string
+}
+
+// inlineTags are the tags the built-in EditorJS inline tools produce. Any other tag in text is reported as raw html.
+var inlineTags = map[string]bool{
+	"a": true, "b": true, "strong": true, "i": true, "em": true, "u": true, "s": true, "del": true,
+	"code": true, "mark": true, "br": true, "sup": true, "sub": true, "span": true, "font": true,
+	"editorjs-style": true,
+}
+
+var (
+	tagNameRegexp = regexp.MustCompile(`<([a-zA-Z][a-zA-Z0-9-]*)`)
+	hrefRegexp    = regexp.MustCompile(`<a\s+[^>]*href=['"]([^'"]*)['"]`)
+)
+
+// Lint reports content problems in the editorJS data. An error is only returned if the data can't be parsed.
+func Lint(editorJSData string, options *LintOptions) ([]LintIssue, error) {
+	if options == nil {
+		options = &LintOptions{}
+	}
+	ejs, err := ParseDocument(editorJSData)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &linter{options: options, issues: []LintIssue{}}
+	for i, block := range ejs.Blocks {
+		l.block = block
+		l.index = i
+		l.lintBlock()
+	}
+	return l.issues, nil
+}
+
+type linter struct {
+	options      *LintOptions
+	issues       []LintIssue
+	block        EditorJSBlock
+	index        int
+	headingLevel int
+}
+
+func (l *linter) report(rule, format string, args ...interface{}) {
+	for _, disabled := range l.options.DisabledRules {
+		if disabled == rule {
+			return
+		}
+	}
+	l.issues = append(l.issues, LintIssue{
+		BlockIndex: l.index,
+		BlockID:    l.block.ID,
+		BlockType:  l.block.Type,
+		Rule:       rule,
+		Message:    fmt.Sprintf(format, args...),
+	})
+}
+
+func (l *linter) lintBlock() {
+	switch l.block.Type {
+	case "paragraph":
+		p := &Paragraph{}
+		if json.Unmarshal(l.block.Data, p) != nil {
+			return
+		}
+		if strings.TrimSpace(plainText(p.Text)) == "" {
+			l.report(LintRuleEmptyParagraph, "paragraph has no text")
+		}
+	case "header":
+		h := &Header{}
+		if json.Unmarshal(l.block.Data, h) != nil {
+			return
+		}
+		if l.headingLevel > 0 && h.Level > l.headingLevel+1 {
+			l.report(LintRuleHeadingSkip, "heading level %d follows heading level %d", h.Level, l.headingLevel)
+		}
+		l.headingLevel = h.Level
+	case "image", "simpleImage":
+		image := &Image{}
+		if json.Unmarshal(l.block.Data, image) != nil {
+			return
+		}
+		if image.File.URL == "" {
+			image.File.URL = image.URL
+		}
+		if strings.TrimSpace(plainText(image.Caption)) == "" {
+			l.report(LintRuleImageCaption, "image %s has no caption or alt text", image.File.URL)
+		}
+		l.lintLink(image.File.URL)
+	case "raw":
+		l.report(LintRuleRawHTML, "raw html block")
+	}
+
+	// the text rules cover the same text as Stats
+	for _, text := range blockTexts(l.block) {
+		l.lintText(text)
+	}
+}
+
+func (l *linter) lintText(text string) {
+	for _, match := range tagNameRegexp.FindAllStringSubmatch(text, -1) {
+		if tag := strings.ToLower(match[1]); !inlineTags[tag] {
+			l.report(LintRuleRawHTML, "text contains <%s> tag", tag)
+		}
+	}
+	for _, match := range hrefRegexp.FindAllStringSubmatch(text, -1) {
+		l.lintLink(match[1])
+	}
+}
+
+func (l *linter) lintLink(link string) {
+	if strings.TrimSpace(link) == "" {
+		l.report(LintRuleBrokenLink, "empty link")
+		return
+	}
+	u, err := url.Parse(link)
+	if err != nil {
+		l.report(LintRuleBrokenLink, "malformed link %q", link)
+		return
+	}
+	if u.Scheme != "" || u.Host != "" || u.Path == "" || l.options.BaseDir == "" {
+		return
+	}
+
+	// root relative links are resolved against BaseDir as well
+	path := filepath.Join(l.options.BaseDir, filepath.FromSlash(u.Path))
+	if _, err := os.Stat(path); err != nil {
+		l.report(LintRuleBrokenLink, "relative link %q does not resolve to a file", link)
+	}
+}
@@ -0,0 +1,71 @@
+package goeditorjs_test
+
+import (
+	"testing"
+
+	"github.com/davidscottmills/goeditorjs"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Lint_Returns_Parse_Err(t *testing.T) {
+	_, err := goeditorjs.Lint(``, nil)
+	require.Error(t, err)
+}
+
+func Test_Lint(t *testing.T) {
+	editorJSData := `{"blocks": [
+		{"type": "header", "data": {"text": "Title", "level": 1}},
+		{"type": "header", "data": {"text": "Too deep", "level": 3}},
+		{"type": "paragraph", "data": {"text": "&nbsp;<br>"}},
+		{"type": "paragraph", "data": {"text": "<a href=\"exists.md\">ok</a> <a href=\"missing.md\">broken</a> <a href=\"https://example.com\">remote</a>"}},
+		{"type": "paragraph", "data": {"text": "<div>block</div><b>bold</b>"}},
+		{"type": "image", "data": {"file": {"url": "https://example.com/a.jpg"}, "caption": ""}},
+		{"type": "raw", "data": {"html": "<div></div>"}}
+	]}`
+	issues, err := goeditorjs.Lint(editorJSData, &goeditorjs.LintOptions{BaseDir: "testdata"})
+	require.NoError(t, err)
+
+	rules := []string{}
+	for _, issue := range issues {
+		rules = append(rules, issue.Rule)
+	}
+	require.Equal(t, []string{
+		goeditorjs.LintRuleHeadingSkip,
+		goeditorjs.LintRuleEmptyParagraph,
+		goeditorjs.LintRuleBrokenLink,
+		goeditorjs.LintRuleRawHTML,
+		goeditorjs.LintRuleImageCaption,
+		goeditorjs.LintRuleRawHTML,
+	}, rules)
+	require.Equal(t, 3, issues[2].BlockIndex)
+}
+
+func Test_Lint_TextBlocks(t *testing.T) {
+	editorJSData := `{"blocks": [
+		{"type": "quote", "data": {"text": "<script>x</script>", "caption": "<a href=\"missing.md\">source</a>"}},
+		{"type": "table", "data": {"content": [["a", "<iframe></iframe>"]]}},
+		{"type": "checklist", "data": {"items": [{"text": "<a href=\"\">todo</a>"}]}},
+		{"type": "warning", "data": {"title": "<img src=\"x\">", "message": "ok"}}
+	]}`
+	issues, err := goeditorjs.Lint(editorJSData, &goeditorjs.LintOptions{BaseDir: "testdata"})
+	require.NoError(t, err)
+
+	result := []string{}
+	for _, issue := range issues {
+		result = append(result, issue.String())
+	}
+	require.Equal(t, []string{
+		"block 0 (quote): raw-html: text contains <script> tag",
+		`block 0 (quote): broken-link: relative link "missing.md" does not resolve to a file`,
+		"block 1 (table): raw-html: text contains <iframe> tag",
+		"block 2 (checklist): broken-link: empty link",
+		"block 3 (warning): raw-html: text contains <img> tag",
+	}, result)
+}
+
+func Test_Lint_DisabledRules(t *testing.T) {
+	editorJSData := `{"blocks": [{"type": "raw", "data": {"html": "<div></div>"}}]}`
+	issues, err := goeditorjs.Lint(editorJSData, &goeditorjs.LintOptions{DisabledRules: []string{goeditorjs.LintRuleRawHTML}})
+	require.NoError(t, err)
+	require.Empty(t, issues)
+}
@@ -10,6 +10,17 @@ import (
 type MarkdownEngine struct {
 	StaticDomain  string
 	BlockHandlers map[string]MarkdownBlockHandler
+	// Dialect is the markdown flavor the built-in handlers generate. If nil, MarkdownGFM is used.
+	Dialect *MarkdownDialect
+	// FrontMatter makes the engine prepend front matter to the generated markdown if set
+	FrontMatter *FrontMatterOptions
+	// Transformers transform the blocks of every document before it is rendered, see WithMarkdownTransformers
+	Transformers []Transformer
+	// footnotes collects the footnotes of the document being generated
+	footnotes *footnoteCollector
+	// unknown makes the engine render blocks without handler or whose handler fails by unknownMarkdownBlockHandler, including the
+	// blocks of nested documents
+	unknown bool
 }
 
 // MarkdownBlockHandler is an interface for a plugable EditorJS HTML generator
@@ -18,6 +29,23 @@ type MarkdownBlockHandler interface {
 	GenerateMarkdown(editorJSBlock EditorJSBlock) (string, error)
 }
 
+// MarkdownEngineBlockHandler is implemented by MarkdownBlockHandlers that need the engine generating them, e.g. to
+// render the documents nested in container blocks. The engine calls GenerateMarkdownWithEngine instead of
+// GenerateMarkdown.
+type MarkdownEngineBlockHandler interface {
+	MarkdownBlockHandler
+	GenerateMarkdownWithEngine(editorJSBlock EditorJSBlock, engine *MarkdownEngine) (string, error)
+}
+
+// MarkdownBlockOwnerHandler is implemented by MarkdownBlockHandlers of container blocks owning the blocks following
+// them. The engine calls GenerateMarkdownWithChildren with the markdown of the owned blocks instead of
+// GenerateMarkdown.
+type MarkdownBlockOwnerHandler interface {
+	MarkdownBlockHandler
+	BlockOwner
+	GenerateMarkdownWithChildren(editorJSBlock EditorJSBlock, children string, engine *MarkdownEngine) (string, error)
+}
+
 type MarkdownEngineOptions func(m *MarkdownEngine)
 
 func WithStaticDomain(domain string) MarkdownEngineOptions {
@@ -45,24 +73,56 @@ func (markdownEngine *MarkdownEngine) RegisterBlockHandlers(handlers ...Markdown
 
 // GenerateMarkdown generates markdown from the editorJS using configured set of markdown handlers
 func (markdownEngine *MarkdownEngine) GenerateMarkdown(editorJSData string) (string, error) {
-	results := []string{}
-	ejs, err := parseEditorJSON(editorJSData)
+	ejs, err := ParseDocument(editorJSData)
 	if err != nil {
 		return "", err
 	}
-	for _, block := range ejs.Blocks {
-		if generator, ok := markdownEngine.BlockHandlers[block.Type]; ok {
-			md, err := generator.GenerateMarkdown(block)
-			if err != nil {
-				return "", err
-			}
-			results = append(results, md)
-		} else {
-			return "", fmt.Errorf("%w, Block Type: %s", ErrBlockHandlerNotFound, block.Type)
-		}
+
+	engine := markdownEngine.document()
+	md, err := engine.GenerateBlocksMarkdown(ejs.Blocks)
+	if err != nil {
+		return "", err
 	}
 
-	return strings.Join(results, "\n\n"), nil
+	return engine.wrap(ejs, engine.appendFootnotes(md))
+}
+
+// document returns a copy of the engine to generate a single document with, holding the state of the document
+func (markdownEngine *MarkdownEngine) document() *MarkdownEngine {
+	engine := *markdownEngine
+	engine.footnotes = newFootnoteCollector()
+	return &engine
+}
+
+// appendFootnotes appends the footnote definitions collected while generating md
+func (markdownEngine *MarkdownEngine) appendFootnotes(md string) string {
+	if footnotes := markdownEngine.footnotes.markdown(markdownEngine.dialect()); footnotes != "" {
+		return md + "\n\n" + footnotes
+	}
+	return md
+}
+
+// wrap prepends the front matter to the generated markdown if the engine is configured to do so
+func (markdownEngine *MarkdownEngine) wrap(ejs *Document, md string) (string, error) {
+	if markdownEngine.FrontMatter == nil {
+		return md, nil
+	}
+	frontMatter, err := frontMatter(markdownEngine.FrontMatter, ejs)
+	if err != nil {
+		return "", err
+	}
+	return frontMatter + "\n\n" + md, nil
+}
+
+// GenerateBlocksMarkdown generates markdown from blocks using configured set of markdown handlers. Handlers of
+// container blocks use it through the engine passed to GenerateMarkdownWithEngine to render the documents nested in
+// their data.
+func (markdownEngine *MarkdownEngine) GenerateBlocksMarkdown(blocks []EditorJSBlock) (string, error) {
+	blocks, err := Pipeline(markdownEngine.Transformers).Transform(blocks)
+	if err != nil {
+		return "", err
+	}
+	return markdownEngine.generateBlocks(blocks)
 }
 
 func unknownMarkdownBlockHandler(data EditorJSBlock) string {
@@ -72,23 +132,87 @@ func unknownMarkdownBlockHandler(data EditorJSBlock) string {
 
 // GenerateMarkdown generates markdown from the editorJS using configured set of markdown handlers
 func (markdownEngine *MarkdownEngine) GenerateMarkdownWithUnknownBlock(editorJSData string) (string, error) {
-	results := []string{}
-	ejs, err := parseEditorJSON(editorJSData)
+	ejs, err := ParseDocument(editorJSData)
+	if err != nil {
+		return "", err
+	}
+
+	engine := markdownEngine.document()
+	engine.unknown = true
+	// blocks don't fail in unknown mode, only the transformers do
+	md, err := engine.GenerateBlocksMarkdown(ejs.Blocks)
 	if err != nil {
 		return "", err
 	}
-	for _, block := range ejs.Blocks {
-		if generator, ok := markdownEngine.BlockHandlers[block.Type]; ok {
-			md, err := generator.GenerateMarkdown(block)
-			if err != nil {
+
+	return engine.wrap(ejs, engine.appendFootnotes(md))
+}
+
+// generateBlocks generates the markdown of blocks. If the engine is in unknown mode, blocks without handler or whose
+// handler fails are rendered by unknownMarkdownBlockHandler instead of returning an error.
+func (markdownEngine *MarkdownEngine) generateBlocks(blocks []EditorJSBlock) (string, error) {
+	results := []string{}
+	for i := 0; i < len(blocks); i++ {
+		block := blocks[i]
+		generator, ok := markdownEngine.BlockHandlers[block.Type]
+		if !ok {
+			if markdownEngine.unknown {
+				results = append(results, unknownMarkdownBlockHandler(block))
+				continue
+			}
+			return "", fmt.Errorf("%w, Block Type: %s", ErrBlockHandlerNotFound, block.Type)
+		}
+
+		md, owned, err := markdownEngine.generateBlock(generator, blocks[i:])
+		if err != nil {
+			if markdownEngine.unknown {
 				results = append(results, unknownMarkdownBlockHandler(block))
+				// the blocks owned by the failing block are part of it
+				i += owned
 				continue
 			}
-			results = append(results, md)
-		} else {
-			results = append(results, unknownMarkdownBlockHandler(block))
+			return "", err
 		}
+		results = append(results, md)
+		i += owned
 	}
 
 	return strings.Join(results, "\n\n"), nil
 }
+
+// generateBlock generates the markdown of the first of blocks with its handler. It returns the number of following
+// blocks the handler owns, which are rendered as its children.
+func (markdownEngine *MarkdownEngine) generateBlock(handler MarkdownBlockHandler, blocks []EditorJSBlock) (string, int, error) {
+	block, owned := blocks[0], 0
+	var md string
+	var err error
+	if markdownEngine.footnotes != nil {
+		markdownEngine.footnotes.reserve(block)
+	}
+	switch h := handler.(type) {
+	case MarkdownBlockOwnerHandler:
+		owned = ownedBlockCount(h, block, blocks[1:])
+		var children string
+		if children, err = markdownEngine.generateBlocks(blocks[1 : 1+owned]); err != nil {
+			return "", owned, err
+		}
+		md, err = h.GenerateMarkdownWithChildren(block, children, markdownEngine)
+	case MarkdownEngineBlockHandler:
+		md, err = h.GenerateMarkdownWithEngine(block, markdownEngine)
+	default:
+		md, err = handler.GenerateMarkdown(block)
+	}
+	if err != nil {
+		return "", owned, err
+	}
+	if markdownEngine.footnotes != nil {
+		md = markdownEngine.footnotes.collect(block, md, markdownFootnoteRef(markdownEngine.dialect()))
+	}
+	if !markdownEngine.dialect().HTML {
+		// markers of footnotes the block has no content for are reduced to their text
+		md = footnoteMarkerRegexp.ReplaceAllStringFunc(md, func(marker string) string {
+			return inlineTagRegexp.ReplaceAllString(marker, "")
+		})
+	}
+	return md, owned, nil
+}
@@ -0,0 +1,331 @@
+package goeditorjs
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// inlineHTMLTagRegexp matches the tag at the start of inline html and captures the slash of closing tags and the name
+var inlineHTMLTagRegexp = regexp.MustCompile(`^<(/?)([a-zA-Z][a-zA-Z0-9-]*)(?:\s[^>]*)?/?>`)
+
+// inlineMathClassRegexp matches the class attribute of the spans the inline math tool wraps formulas in
+var inlineMathClassRegexp = regexp.MustCompile(`\sclass="(?:[^"]*\s)?inline-math(?:\s[^"]*)?"`)
+
+// markdownHardBreak is the markdown of a <br>, a backslash at the end of the line
+const markdownHardBreak = "\\\n"
+
+// emphasisMarkers maps the inline tags that have a markdown equivalent to their delimiter
+var emphasisMarkers = map[string]string{"b": "**", "strong": "**", "i": "*", "em": "*"}
+
+// markdownInline converts the inline html of EditorJS text to markdown in dialect. Text is decoded and escaped, links,
+// code, inline math, bold and italic are converted to markdown and <br> to hard line breaks. Other tags, like <u>, are
+// kept as inline html if the dialect permits html and reduced to their text otherwise. The markers of footnotes are
+// always kept, the engine replaces them. Line starts are escaped, so the result can be placed at the start of a block.
+func markdownInline(text string, dialect *MarkdownDialect) string {
+	return inlineMarkdown(text, markdownHardBreak, dialect)
+}
+
+// markdownInlineLine converts the inline html of EditorJS text to markdown like markdownInline, but for markdown that
+// has to stay on a single line, like headings or table cells: <br> and line breaks of the text become lineBreak.
+func markdownInlineLine(text, lineBreak string, dialect *MarkdownDialect) string {
+	return strings.ReplaceAll(inlineMarkdown(text, lineBreak, dialect), "\n", lineBreak)
+}
+
+// inlineMarkdown converts the inline html of text to markdown in dialect, writing lineBreak for <br>. Breaks at the end
+// of the text are dropped, as they don't break any line.
+func inlineMarkdown(text, lineBreak string, dialect *MarkdownDialect) string {
+	sb := strings.Builder{}
+	// breaks holds the output positions of the line breaks
+	breaks := []int{}
+	// links holds the href and the output position of the open <a> tags. href is empty for anchors without href or
+	// with an unsafe href, html is set if their tag is kept as html.
+	type link struct {
+		href  string
+		start int
+		html  bool
+	}
+	links := []link{}
+	// emphasis holds the delimiter and the output position of the content of the open <b> and <i> tags
+	type emphasis struct {
+		marker string
+		start  int
+	}
+	emphases := []emphasis{}
+	isBreak := func(pos int) bool {
+		for _, b := range breaks {
+			if b == pos {
+				return true
+			}
+		}
+		return false
+	}
+	// closeEmphasis closes the emphasis at index i. Whitespace and breaks at the ends of the content are moved outside
+	// the delimiters, where they don't prevent them from being taken as emphasis, and empty emphasis is dropped.
+	closeEmphasis := func(i int) {
+		e := emphases[i]
+		emphases = append(emphases[:i], emphases[i+1:]...)
+		md := sb.String()
+		first, last := e.start, len(md)
+		for first < last {
+			if isBreak(first) {
+				first += len(lineBreak)
+			} else if strings.IndexByte(" \t\n", md[first]) >= 0 {
+				first++
+			} else {
+				break
+			}
+		}
+		for first < last {
+			if last-len(lineBreak) >= first && isBreak(last-len(lineBreak)) {
+				last -= len(lineBreak)
+			} else if strings.IndexByte(" \t\n", md[last-1]) >= 0 {
+				last--
+			} else {
+				break
+			}
+		}
+		before, lead, trimmed, trail := md[:e.start-len(e.marker)], md[e.start:first], md[first:last], md[last:]
+		sb.Reset()
+		sb.WriteString(before + lead)
+		if trimmed != "" {
+			sb.WriteString(e.marker + trimmed + e.marker)
+		}
+		sb.WriteString(trail)
+
+		// the positions of the breaks, links and emphasis in the content move with it
+		shift := func(pos int) int {
+			switch {
+			case pos < e.start:
+				return pos
+			case pos < e.start+len(lead) || trimmed == "":
+				return pos - len(e.marker)
+			case pos < e.start+len(lead)+len(trimmed):
+				return pos
+			}
+			return pos + len(e.marker)
+		}
+		for j := range breaks {
+			breaks[j] = shift(breaks[j])
+		}
+		for j := range links {
+			links[j].start = shift(links[j].start)
+		}
+		for j := range emphases {
+			emphases[j].start = shift(emphases[j].start)
+		}
+	}
+	// footnotes counts the open markers of footnotes, whose closing tags are kept
+	footnotes := 0
+
+	for len(text) > 0 {
+		lt := strings.IndexByte(text, '<')
+		if lt < 0 {
+			lt = len(text)
+		}
+		if lt > 0 {
+			sb.WriteString(escapeMarkdown(html.UnescapeString(text[:lt])))
+			text = text[lt:]
+			continue
+		}
+
+		match := inlineHTMLTagRegexp.FindStringSubmatch(text)
+		if match == nil {
+			sb.WriteString(`\<`)
+			text = text[1:]
+			continue
+		}
+		tag, closing, name := match[0], match[1] == "/", strings.ToLower(match[2])
+		text = text[len(tag):]
+
+		switch {
+		case name == "br":
+			breaks = append(breaks, sb.Len())
+			sb.WriteString(lineBreak)
+		case name == "a" && !closing:
+			href := ""
+			if m := hrefRegexp.FindStringSubmatch(tag); m != nil {
+				href = html.UnescapeString(m[1])
+			}
+			l := link{href: href}
+			switch {
+			case href == "":
+				l.html = dialect.HTML
+				if l.html {
+					sb.WriteString(tag)
+				}
+			case !safeURL(href):
+				// only the text of links to unsafe urls is kept
+				l.href = ""
+			default:
+				sb.WriteString("[")
+			}
+			l.start = sb.Len()
+			links = append(links, l)
+		case name == "a" && len(links) > 0:
+			l := links[len(links)-1]
+			links = links[:len(links)-1]
+			if l.href == "" {
+				if l.html {
+					sb.WriteString(tag)
+				}
+				break
+			}
+			if sb.Len() == l.start {
+				sb.WriteString(escapeMarkdown(l.href))
+			}
+			sb.WriteString("](" + markdownURL(l.href) + ")")
+		case name == "code" && !closing:
+			content, rest := inlineElementContent(text, "code")
+			text = rest
+			sb.WriteString(markdownCodeSpan(plainText(content)))
+		case name == "span" && !closing && inlineMathClassRegexp.MatchString(tag):
+			content, rest := inlineElementContent(text, "span")
+			text = rest
+			sb.WriteString("$" + strings.TrimSpace(plainText(content)) + "$")
+		case emphasisMarkers[name] != "" && !closing:
+			sb.WriteString(emphasisMarkers[name])
+			emphases = append(emphases, emphasis{marker: emphasisMarkers[name], start: sb.Len()})
+		case emphasisMarkers[name] != "":
+			for i := len(emphases) - 1; i >= 0; i-- {
+				if emphases[i].marker == emphasisMarkers[name] {
+					closeEmphasis(i)
+					break
+				}
+			}
+		case dialect.HTML:
+			sb.WriteString(tag)
+		case name == "sup" && !closing && footnoteMarkerRegexp.MatchString(tag+"</sup>"):
+			sb.WriteString(tag)
+			footnotes++
+		case name == "sup" && footnotes > 0:
+			sb.WriteString(tag)
+			footnotes--
+		}
+	}
+	// emphasis and links that were never closed
+	for len(emphases) > 0 {
+		closeEmphasis(len(emphases) - 1)
+	}
+	md := sb.String()
+	for i := len(breaks) - 1; i >= 0 && strings.TrimSpace(md[breaks[i]+len(lineBreak):]) == ""; i-- {
+		md = strings.TrimRight(md[:breaks[i]], " ")
+	}
+	sb.Reset()
+	sb.WriteString(md)
+	for i := len(links) - 1; i >= 0; i-- {
+		if links[i].href != "" {
+			sb.WriteString("](" + markdownURL(links[i].href) + ")")
+		}
+	}
+
+	return escapeMarkdownLineStarts(sb.String())
+}
+
+// inlineElementContent returns the content of the element named name up to its closing tag and the text following it
+func inlineElementContent(text, name string) (string, string) {
+	closing := regexp.MustCompile(`(?i)</` + name + `\s*>`)
+	loc := closing.FindStringIndex(text)
+	if loc == nil {
+		return text, ""
+	}
+	return text[:loc[0]], text[loc[1]:]
+}
+
+// entityRegexp matches the start of a character reference
+var entityRegexp = regexp.MustCompile(`^&(?:#[0-9]{1,7}|#[xX][0-9a-fA-F]{1,6}|[a-zA-Z][a-zA-Z0-9]*);`)
+
+// escapeMarkdown escapes the characters of plain text that would be parsed as inline markdown: emphasis, code,
+// links, strikethrough, math, html and character references. Underscores are only escaped at word boundaries, as
+// intraword underscores don't start emphasis.
+func escapeMarkdown(text string) string {
+	sb := strings.Builder{}
+	prev := ' '
+	for i, r := range text {
+		next, _ := utf8.DecodeRuneInString(text[i+utf8.RuneLen(r):])
+		escape := false
+		switch r {
+		case '\\', '`', '*', '[', ']', '~', '$':
+			escape = true
+		case '_':
+			escape = !isWordRune(prev) || !isWordRune(next)
+		case '<':
+			escape = next == '/' || next == '!' || next == '?' || unicode.IsLetter(next)
+		case '&':
+			escape = entityRegexp.MatchString(text[i:])
+		}
+		if escape {
+			sb.WriteByte('\\')
+		}
+		sb.WriteRune(r)
+		prev = r
+	}
+	return sb.String()
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+var (
+	// blockStartRegexp matches the line starts that open a block: atx headings, blockquotes, bullet list items and
+	// ordered list items. The submatch is the character to escape.
+	blockStartRegexp = regexp.MustCompile(`^( {0,3})(?:(#)#{0,5}(?:[ \t]|$)|(>)|([-+])(?:[ \t]|$)|[0-9]{1,9}([.)])(?:[ \t]|$))`)
+	// underlineRegexp matches the lines that are setext heading underlines or thematic breaks
+	underlineRegexp = regexp.MustCompile(`^( {0,3})([-=])[-= \t]*$`)
+)
+
+// escapeMarkdownLineStarts escapes the starts of the lines of md that would open a block or end a paragraph
+func escapeMarkdownLineStarts(md string) string {
+	lines := strings.Split(md, "\n")
+	for i, line := range lines {
+		if m := underlineRegexp.FindStringSubmatchIndex(line); m != nil {
+			lines[i] = line[:m[4]] + `\` + line[m[4]:]
+			continue
+		}
+		m := blockStartRegexp.FindStringSubmatchIndex(line)
+		if m == nil {
+			continue
+		}
+		for g := 2; g <= 5; g++ {
+			if start := m[2*g]; start >= 0 {
+				lines[i] = line[:start] + `\` + line[start:]
+				break
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// markdownURL returns url as destination of a markdown link. Urls with spaces, parentheses or angle brackets are
+// enclosed in angle brackets.
+func markdownURL(url string) string {
+	if !strings.ContainsAny(url, " ()<>") {
+		return url
+	}
+	return "<" + strings.NewReplacer("<", `\<`, ">", `\>`).Replace(url) + ">"
+}
+
+// markdownCodeSpan returns code as code span. The delimiters are longer than any backtick run in code and padded
+// with spaces if code starts or ends with a backtick.
+func markdownCodeSpan(code string) string {
+	code = strings.ReplaceAll(code, "\n", " ")
+	delimiter := "`"
+	for _, run := range backtickRunRegexp.FindAllString(code, -1) {
+		if len(run) >= len(delimiter) {
+			delimiter = strings.Repeat("`", len(run)+1)
+		}
+	}
+	if strings.HasPrefix(code, "`") || strings.HasSuffix(code, "`") {
+		code = " " + code + " "
+	}
+	return fmt.Sprintf("%s%s%s", delimiter, code, delimiter)
+}
+
+// markdownListItem returns item prefixed by marker, indenting its continuation lines to stay part of the item
+func markdownListItem(marker, item string) string {
+	return marker + strings.ReplaceAll(item, "\n", "\n"+strings.Repeat(" ", utf8.RuneCountInString(marker)))
+}
@@ -0,0 +1,174 @@
+package goeditorjs_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/davidscottmills/goeditorjs"
+	"github.com/stretchr/testify/require"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	extast "github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/text"
+)
+
+func goldenMarkdownEngine() *goeditorjs.MarkdownEngine {
+	eng := goeditorjs.NewMarkdownEngine()
+	eng.RegisterBlockHandlers(&goeditorjs.HeaderHandler{}, &goeditorjs.ParagraphHandler{}, &goeditorjs.ListHandler{},
+		&goeditorjs.QuoteHandler{}, &goeditorjs.DelimiterHandler{}, &goeditorjs.CodeHandler{}, &goeditorjs.TableHandler{},
+		&goeditorjs.ChecklistHandler{})
+	return eng
+}
+
+// Test_MarkdownEngine_Golden compares the markdown of the documents in testdata/markdown to the golden files next to
+// them, and checks that a CommonMark parser parses the markdown to the blocks of the document
+func Test_MarkdownEngine_Golden(t *testing.T) {
+	files, err := filepath.Glob("testdata/markdown/*.json")
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	for _, file := range files {
+		input, err := ioutil.ReadFile(file)
+		require.NoError(t, err)
+		expected, err := ioutil.ReadFile(strings.TrimSuffix(file, ".json") + ".md")
+		require.NoError(t, err)
+
+		result, err := goldenMarkdownEngine().GenerateMarkdown(string(input))
+		require.NoError(t, err, file)
+		require.Equal(t, string(expected), result+"\n", file)
+		require.Equal(t, documentStructure(t, input), markdownStructure(t, result), file)
+	}
+}
+
+// Test_MarkdownEngine_Escaping_RoundTrip compares the markdown of plain text to the expected escaping, and checks that
+// removing the backslash escapes gives back the text and that no inline markdown starts unescaped
+func Test_MarkdownEngine_Escaping_RoundTrip(t *testing.T) {
+	testData := []struct {
+		text     string
+		expected string
+	}{
+		{"1. one", `1\. one`}, {"10) ten", `10\) ten`}, {"- dash", `\- dash`}, {"+ plus", `\+ plus`},
+		{"* star", `\* star`}, {"# hash", `\# hash`}, {"###### six", `\###### six`}, {"> quote", `\> quote`},
+		{"---", `\---`}, {"===", `\===`}, {"***", `\*\*\*`}, {"_under_", `\_under\_`},
+		{"__strong__", `\_\_strong\_\_`}, {"`tick`", "\\`tick\\`"}, {"``", "\\`\\`"}, {"[a](b)", `\[a\](b)`},
+		{"![a](b)", `!\[a\](b)`}, {"~~strike~~", `\~\~strike\~\~`}, {"$x$", `\$x\$`}, {"a\\b", `a\\b`},
+		{"\\*", `\\\*`}, {"&lt;b&gt;x&lt;/b&gt;", `\<b>x\</b>`}, {"&amp;amp;", `\&amp;`}, {"&amp;#35;", `\&#35;`},
+		{"&lt;!-- comment --&gt;", `\<!-- comment -->`}, {"&lt;http://example.com&gt;", `\<http://example.com>`},
+		{"x &lt; y", `x < y`}, {"snake_case_name", `snake_case_name`}, {"日本語_テキスト", `日本語_テキスト`},
+		{"&nbsp;&nbsp;indent", "\u00a0\u00a0indent"},
+	}
+
+	eng := goldenMarkdownEngine()
+	for _, td := range testData {
+		data, err := json.Marshal(map[string]interface{}{"blocks": []interface{}{
+			map[string]interface{}{"type": "paragraph", "data": map[string]string{"text": td.text, "alignment": "left"}},
+		}})
+		require.NoError(t, err)
+
+		result, err := eng.GenerateMarkdown(string(data))
+		require.NoError(t, err)
+		require.Equal(t, td.expected, result, "text: %q", td.text)
+		require.Equal(t, []string{"p"}, markdownStructure(t, result), "text: %q, markdown: %q", td.text, result)
+		require.Equal(t, html.UnescapeString(td.text), unescapeMarkdown(result), "text: %q, markdown: %q", td.text, result)
+		require.False(t, unescapedInlineRegexp.MatchString(result), "text: %q, markdown: %q", td.text, result)
+	}
+}
+
+// unescapedInlineRegexp matches the characters starting inline markdown that aren't escaped by a backslash
+var unescapedInlineRegexp = regexp.MustCompile("(?:^|[^\\\\])(?:\\\\\\\\)*[*`\\[\\]~$]|(?:^|[^\\\\])<[a-zA-Z/!?]|(?:^|[^\\\\])&[#a-zA-Z0-9]+;")
+
+// backslashEscapeRegexp matches the backslash escapes of markdown
+var backslashEscapeRegexp = regexp.MustCompile(`\\([!-/:-@\[-` + "`" + `{-~])`)
+
+func unescapeMarkdown(md string) string {
+	return backslashEscapeRegexp.ReplaceAllString(md, "$1")
+}
+
+// documentStructure returns the kinds of the blocks of an EditorJS document as markdownStructure reports them
+func documentStructure(t *testing.T, input []byte) []string {
+	doc := struct {
+		Blocks []struct {
+			Type string          `json:"type"`
+			Data json.RawMessage `json:"data"`
+		} `json:"blocks"`
+	}{}
+	require.NoError(t, json.Unmarshal(input, &doc))
+
+	kinds := []string{}
+	for _, block := range doc.Blocks {
+		data := struct {
+			Level int           `json:"level"`
+			Style string        `json:"style"`
+			Items []interface{} `json:"items"`
+		}{}
+		require.NoError(t, json.Unmarshal(block.Data, &data))
+
+		switch block.Type {
+		case "header":
+			kinds = append(kinds, fmt.Sprintf("h%d", data.Level))
+		case "paragraph":
+			kinds = append(kinds, "p")
+		case "list", "checklist":
+			kind := "ul"
+			if data.Style == "ordered" {
+				kind = "ol"
+			}
+			kinds = append(kinds, fmt.Sprintf("%s:%d", kind, len(data.Items)))
+		case "quote":
+			kinds = append(kinds, "blockquote")
+		case "delimiter":
+			kinds = append(kinds, "hr")
+		case "code":
+			kinds = append(kinds, "pre")
+		case "table":
+			kinds = append(kinds, "table")
+		default:
+			t.Fatalf("no structure for block type %s", block.Type)
+		}
+	}
+	return kinds
+}
+
+// markdownStructure returns the kinds of the top level blocks goldmark parses md to, with GFM tables and task lists.
+// Loose lists are reported apart, as the blocks of the document only generate tight lists.
+func markdownStructure(t *testing.T, md string) []string {
+	parser := goldmark.New(goldmark.WithExtensions(extension.Table, extension.TaskList)).Parser()
+	doc := parser.Parse(text.NewReader([]byte(md)))
+
+	kinds := []string{}
+	for node := doc.FirstChild(); node != nil; node = node.NextSibling() {
+		switch n := node.(type) {
+		case *ast.Heading:
+			kinds = append(kinds, fmt.Sprintf("h%d", n.Level))
+		case *ast.Paragraph:
+			kinds = append(kinds, "p")
+		case *ast.List:
+			kind := "ul"
+			if n.IsOrdered() {
+				kind = "ol"
+			}
+			if !n.IsTight {
+				kind = "loose " + kind
+			}
+			kinds = append(kinds, fmt.Sprintf("%s:%d", kind, n.ChildCount()))
+		case *ast.Blockquote:
+			kinds = append(kinds, "blockquote")
+		case *ast.ThematicBreak:
+			kinds = append(kinds, "hr")
+		case *ast.FencedCodeBlock, *ast.CodeBlock:
+			kinds = append(kinds, "pre")
+		case *extast.Table:
+			kinds = append(kinds, "table")
+		default:
+			t.Fatalf("unexpected %s block in markdown %q", node.Kind(), md)
+		}
+	}
+	return kinds
+}
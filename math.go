@@ -0,0 +1,625 @@
+package goeditorjs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MathRenderer renders TeX formulas for HTML output
+type MathRenderer interface {
+	// RenderMath returns the formula tex as HTML. display is true for math blocks and false for inline math.
+	// The result is inserted into the output as is, so it must be escaped by the MathRenderer.
+	RenderMath(tex string, display bool) (string, error)
+}
+
+// MathRendererFunc adapts a function, e.g. a wrapper around a KaTeX binding, to a MathRenderer
+type MathRendererFunc func(tex string, display bool) (string, error)
+
+// RenderMath calls f(tex, display)
+func (f MathRendererFunc) RenderMath(tex string, display bool) (string, error) {
+	return f(tex, display)
+}
+
+// WithMathRenderer sets the MathRenderer the HTMLEngine renders inline math with. It is also used by MathHandlers
+// without a renderer of their own.
+func WithMathRenderer(renderer MathRenderer) HTMLEngineOptions {
+	return func(h *HTMLEngine) {
+		h.MathRenderer = renderer
+	}
+}
+
+// MathHandler is the default MathHandler for EditorJS HTML and markdown generation of math blocks
+type MathHandler struct {
+	// Options are made available to the GenerateHTML function.
+	// If not provided, the formula is rendered by the engine's MathRenderer.
+	Options *MathHandlerOptions
+	// Classes override the engine's ClassMap for the elements this handler emits. The key is "math".
+	Classes ClassMap
+}
+
+// MathHandlerOptions are the options available to the MathHandler
+type MathHandlerOptions struct {
+	// Renderer renders the formula, e.g. &MathMLRenderer{}. If neither the handler nor the engine has a renderer,
+	// the escaped TeX is wrapped in \[ \] delimiters for client side rendering with KaTeX or MathJax.
+	Renderer MathRenderer
+}
+
+func (*MathHandler) parse(editorJSBlock EditorJSBlock) (*Math, error) {
+	math := &Math{}
+	if err := json.Unmarshal(editorJSBlock.Data, math); err != nil {
+		return nil, err
+	}
+	math.Text = strings.TrimSpace(math.Text)
+	return math, nil
+}
+
+// Type "math"
+func (*MathHandler) Type() string {
+	return "math"
+}
+
+// GenerateHTML generates html for MathBlocks
+func (h *MathHandler) GenerateHTML(editorJSBlock EditorJSBlock) (string, error) {
+	return h.GenerateHTMLWithEngine(editorJSBlock, nil)
+}
+
+// GenerateHTMLWithEngine generates html for MathBlocks using the engine's ClassMap and MathRenderer
+func (h *MathHandler) GenerateHTMLWithEngine(editorJSBlock EditorJSBlock, engine *HTMLEngine) (string, error) {
+	math, err := h.parse(editorJSBlock)
+	if err != nil {
+		return "", err
+	}
+
+	var renderer MathRenderer
+	if h.Options != nil && h.Options.Renderer != nil {
+		renderer = h.Options.Renderer
+	} else if engine != nil {
+		renderer = engine.MathRenderer
+	}
+
+	content, err := renderMath(renderer, math.Text, true)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(`<div%s>%s</div>`, classAttr(h.Classes, engine, "math", "math"), content), nil
+}
+
+// GenerateMarkdown generates markdown for MathBlocks
+func (h *MathHandler) GenerateMarkdown(editorJSBlock EditorJSBlock) (string, error) {
+	math, err := h.parse(editorJSBlock)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("$$\n%s\n$$", math.Text), nil
+}
+
+// renderMath renders tex with renderer or, if renderer is nil, as escaped TeX in KaTeX/MathJax delimiters
+func renderMath(renderer MathRenderer, tex string, display bool) (string, error) {
+	if renderer != nil {
+		return renderer.RenderMath(tex, display)
+	}
+	if display {
+		return `\[` + html.EscapeString(tex) + `\]`, nil
+	}
+	return `\(` + html.EscapeString(tex) + `\)`, nil
+}
+
+// inlineMathRegexp matches the spans the inline math tool wraps formulas in
+var inlineMathRegexp = regexp.MustCompile(`(?s)<span[^>]*\sclass="(?:[^"]*\s)?inline-math(?:\s[^"]*)?"[^>]*>(.*?)</span>`)
+
+// renderInlineMath replaces the inline math spans of text with the formulas rendered by renderer
+func renderInlineMath(text string, renderer MathRenderer, engine *HTMLEngine) (string, error) {
+	var renderErr error
+	text = inlineMathRegexp.ReplaceAllStringFunc(text, func(span string) string {
+		tex := strings.TrimSpace(plainText(inlineMathRegexp.FindStringSubmatch(span)[1]))
+		content, err := renderMath(renderer, tex, false)
+		if err != nil {
+			renderErr = err
+			return span
+		}
+		return fmt.Sprintf(`<span%s>%s</span>`, classAttr(nil, engine, "math-inline", "math-inline"), content)
+	})
+	return text, renderErr
+}
+
+// MathMLRenderer is a dependency free MathRenderer that renders a common subset of TeX to MathML: groups, scripts,
+// fractions, roots, \left/\right, matrix environments, greek letters, common operators and functions, \text and
+// font commands. Unknown commands are rendered as merror elements.
+type MathMLRenderer struct{}
+
+// RenderMath renders tex as a MathML math element with the TeX as annotation
+func (*MathMLRenderer) RenderMath(tex string, display bool) (string, error) {
+	p := &texParser{tex: tex, display: display}
+	nodes, err := p.parseRow()
+	if err != nil {
+		return "", err
+	}
+	if tok := p.peek(); tok != "" {
+		return "", fmt.Errorf("math: unexpected %s at offset %d", tok, p.pos)
+	}
+
+	displayAttr := ""
+	if display {
+		displayAttr = ` display="block"`
+	}
+	return fmt.Sprintf(`<math xmlns="http://www.w3.org/1998/Math/MathML"%s><semantics>%s<annotation encoding="application/x-tex">%s</annotation></semantics></math>`,
+		displayAttr, mrow(nodes), html.EscapeString(tex)), nil
+}
+
+var errMathEnd = errors.New("math: unexpected end of formula")
+
+var (
+	texGreek = map[string]string{
+		"alpha": "α", "beta": "β", "gamma": "γ", "delta": "δ", "epsilon": "ϵ", "varepsilon": "ε", "zeta": "ζ",
+		"eta": "η", "theta": "θ", "vartheta": "ϑ", "iota": "ι", "kappa": "κ", "lambda": "λ", "mu": "μ", "nu": "ν",
+		"xi": "ξ", "pi": "π", "varpi": "ϖ", "rho": "ρ", "varrho": "ϱ", "sigma": "σ", "varsigma": "ς", "tau": "τ",
+		"upsilon": "υ", "phi": "ϕ", "varphi": "φ", "chi": "χ", "psi": "ψ", "omega": "ω", "Gamma": "Γ", "Delta": "Δ",
+		"Theta": "Θ", "Lambda": "Λ", "Xi": "Ξ", "Pi": "Π", "Sigma": "Σ", "Upsilon": "Υ", "Phi": "Φ", "Psi": "Ψ",
+		"Omega": "Ω", "infty": "∞", "partial": "∂", "nabla": "∇", "ell": "ℓ", "hbar": "ℏ", "emptyset": "∅",
+	}
+	texOperators = map[string]string{
+		"cdot": "⋅", "times": "×", "div": "÷", "pm": "±", "mp": "∓", "ast": "∗", "circ": "∘", "bullet": "∙",
+		"leq": "≤", "le": "≤", "geq": "≥", "ge": "≥", "neq": "≠", "ne": "≠", "approx": "≈", "equiv": "≡",
+		"sim": "∼", "simeq": "≃", "cong": "≅", "propto": "∝", "ll": "≪", "gg": "≫", "in": "∈", "notin": "∉",
+		"ni": "∋", "subset": "⊂", "supset": "⊃", "subseteq": "⊆", "supseteq": "⊇", "cup": "∪", "cap": "∩",
+		"setminus": "∖", "wedge": "∧", "land": "∧", "vee": "∨", "lor": "∨", "neg": "¬", "lnot": "¬",
+		"forall": "∀", "exists": "∃", "to": "→", "rightarrow": "→", "leftarrow": "←", "gets": "←",
+		"leftrightarrow": "↔", "Rightarrow": "⇒", "Leftarrow": "⇐", "Leftrightarrow": "⇔", "implies": "⟹",
+		"iff": "⟺", "mapsto": "↦", "ldots": "…", "cdots": "⋯", "vdots": "⋮", "ddots": "⋱", "perp": "⊥",
+		"parallel": "∥", "mid": "∣", "langle": "⟨", "rangle": "⟩", "lfloor": "⌊", "rfloor": "⌋", "lceil": "⌈",
+		"rceil": "⌉", "{": "{", "}": "}", "|": "‖", "vert": "|", "Vert": "‖",
+	}
+	texLargeOperators = map[string]string{
+		"sum": "∑", "prod": "∏", "coprod": "∐", "int": "∫", "iint": "∬", "iiint": "∭", "oint": "∮",
+		"bigcup": "⋃", "bigcap": "⋂",
+	}
+	texFunctions = map[string]bool{
+		"sin": true, "cos": true, "tan": true, "cot": true, "sec": true, "csc": true, "arcsin": true, "arccos": true,
+		"arctan": true, "sinh": true, "cosh": true, "tanh": true, "log": true, "ln": true, "lg": true, "exp": true,
+		"det": true, "dim": true, "ker": true, "deg": true, "gcd": true, "arg": true, "Pr": true, "lim": true,
+		"max": true, "min": true, "sup": true, "inf": true, "limsup": true, "liminf": true,
+	}
+	// texLimits are the functions whose scripts are set under and over them in display mode
+	texLimits = map[string]bool{
+		"lim": true, "max": true, "min": true, "sup": true, "inf": true, "limsup": true, "liminf": true, "det": true,
+		"gcd": true, "Pr": true,
+	}
+	texSpaces = map[string]string{
+		",": "0.1667em", ":": "0.2222em", ">": "0.2222em", ";": "0.2778em", " ": "0.2778em", "quad": "1em",
+		"qquad": "2em", "!": "-0.1667em",
+	}
+	texFonts = map[string]string{
+		"mathbf": "bold", "mathit": "italic", "mathrm": "normal", "mathbb": "double-struck", "mathcal": "script",
+		"mathfrak": "fraktur", "mathsf": "sans-serif", "mathtt": "monospace", "boldsymbol": "bold-italic",
+	}
+	texAccents = map[string]string{
+		"hat": "^", "widehat": "^", "bar": "¯", "overline": "¯", "vec": "→", "dot": "˙", "ddot": "¨",
+		"tilde": "~", "widetilde": "~",
+	}
+	// texMatrixFences are the fences of the matrix environments
+	texMatrixFences = map[string][2]string{
+		"matrix": {"", ""}, "pmatrix": {"(", ")"}, "bmatrix": {"[", "]"}, "Bmatrix": {"{", "}"},
+		"vmatrix": {"|", "|"}, "Vmatrix": {"‖", "‖"}, "cases": {"{", ""}, "aligned": {"", ""}, "align": {"", ""},
+		"array": {"", ""},
+	}
+)
+
+// texParser is a recursive descent parser turning TeX into MathML
+type texParser struct {
+	tex     string
+	pos     int
+	display bool
+	// envDepth and optDepth count the open environments and optional arguments, whose rows end at "&", "\\" and "]"
+	envDepth int
+	optDepth int
+}
+
+// peek returns the next token without consuming it. Tokens are commands like "\frac", runs of digits and single
+// characters. Whitespace is skipped.
+func (p *texParser) peek() string {
+	for p.pos < len(p.tex) && unicode.IsSpace(rune(p.tex[p.pos])) {
+		p.pos++
+	}
+	if p.pos >= len(p.tex) {
+		return ""
+	}
+
+	rest := p.tex[p.pos:]
+	switch c := rest[0]; {
+	case c == '\\':
+		if len(rest) == 1 {
+			return `\`
+		}
+		end := 1
+		for end < len(rest) && isASCIILetter(rest[end]) {
+			end++
+		}
+		if end == 1 {
+			_, size := utf8.DecodeRuneInString(rest[1:])
+			end += size
+		}
+		return rest[:end]
+	case c >= '0' && c <= '9':
+		end := 1
+		for end < len(rest) && (rest[end] >= '0' && rest[end] <= '9' || rest[end] == '.' && end+1 < len(rest) && rest[end+1] >= '0' && rest[end+1] <= '9') {
+			end++
+		}
+		return rest[:end]
+	}
+	_, size := utf8.DecodeRuneInString(rest)
+	return rest[:size]
+}
+
+func (p *texParser) next() string {
+	tok := p.peek()
+	p.pos += len(tok)
+	return tok
+}
+
+// expect consumes the token tok or fails
+func (p *texParser) expect(tok string) error {
+	switch next := p.next(); next {
+	case tok:
+		return nil
+	case "":
+		return errMathEnd
+	default:
+		return fmt.Errorf("math: expected %s, got %s at offset %d", tok, next, p.pos-len(next))
+	}
+}
+
+// rawGroup returns the text of the brace group at the current position, e.g. the argument of \text
+func (p *texParser) rawGroup() (string, error) {
+	if err := p.expect("{"); err != nil {
+		return "", err
+	}
+	depth := 1
+	for i := p.pos; i < len(p.tex); i++ {
+		switch p.tex[i] {
+		case '\\':
+			i++
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				text := p.tex[p.pos:i]
+				p.pos = i + 1
+				return text, nil
+			}
+		}
+	}
+	return "", errMathEnd
+}
+
+// parseRow parses nodes until the end of the formula or a token closing the row
+func (p *texParser) parseRow() ([]string, error) {
+	nodes := []string{}
+	for {
+		switch tok := p.peek(); {
+		case tok == "" || tok == "}" || tok == `\right` || tok == `\end`,
+			p.envDepth > 0 && (tok == "&" || tok == `\\`),
+			p.optDepth > 0 && tok == "]":
+			return nodes, nil
+		}
+		node, err := p.parseScripts()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+	}
+}
+
+// parseScripts parses an atom and its sub- and superscripts
+func (p *texParser) parseScripts() (string, error) {
+	tok := p.peek()
+	base, err := p.parseAtom()
+	if err != nil {
+		return "", err
+	}
+
+	var sub string
+	sup := []string{}
+	for {
+		switch p.peek() {
+		case "_":
+			p.next()
+			if sub, err = p.parseArg(); err != nil {
+				return "", err
+			}
+			continue
+		case "^":
+			p.next()
+			arg, err := p.parseArg()
+			if err != nil {
+				return "", err
+			}
+			sup = append(sup, arg)
+			continue
+		case "'":
+			p.next()
+			sup = append(sup, "<mo>′</mo>")
+			continue
+		}
+		break
+	}
+
+	name := strings.TrimPrefix(tok, `\`)
+	under := p.display && (texLimits[name] || texLargeOperators[name] != "" && !strings.Contains(name, "int"))
+	switch {
+	case sub != "" && len(sup) > 0 && under:
+		return fmt.Sprintf("<munderover>%s%s%s</munderover>", base, sub, mrow(sup)), nil
+	case sub != "" && len(sup) > 0:
+		return fmt.Sprintf("<msubsup>%s%s%s</msubsup>", base, sub, mrow(sup)), nil
+	case sub != "" && under:
+		return fmt.Sprintf("<munder>%s%s</munder>", base, sub), nil
+	case sub != "":
+		return fmt.Sprintf("<msub>%s%s</msub>", base, sub), nil
+	case len(sup) > 0 && under:
+		return fmt.Sprintf("<mover>%s%s</mover>", base, mrow(sup)), nil
+	case len(sup) > 0:
+		return fmt.Sprintf("<msup>%s%s</msup>", base, mrow(sup)), nil
+	}
+	return base, nil
+}
+
+// parseArg parses the argument of a command or script, which is either a group or a single token
+func (p *texParser) parseArg() (string, error) {
+	switch p.peek() {
+	case "":
+		return "", errMathEnd
+	case "{":
+		return p.parseAtom()
+	}
+	tok := p.peek()
+	if len(tok) > 1 && tok[0] >= '0' && tok[0] <= '9' {
+		// only the first digit is the argument, e.g. \frac12
+		p.pos++
+		return fmt.Sprintf("<mn>%s</mn>", tok[:1]), nil
+	}
+	return p.parseAtom()
+}
+
+// parseAtom parses a single node
+func (p *texParser) parseAtom() (string, error) {
+	tok := p.next()
+	switch {
+	case tok == "":
+		return "", errMathEnd
+	case tok == "{":
+		nodes, err := p.parseRow()
+		if err != nil {
+			return "", err
+		}
+		if err := p.expect("}"); err != nil {
+			return "", err
+		}
+		return mrow(nodes), nil
+	case tok == "}":
+		return "", fmt.Errorf("math: unexpected } at offset %d", p.pos-1)
+	case tok == "_" || tok == "^":
+		p.pos--
+		return "<mrow></mrow>", nil
+	case tok[0] >= '0' && tok[0] <= '9':
+		return fmt.Sprintf("<mn>%s</mn>", tok), nil
+	case tok[0] != '\\':
+		r, _ := utf8.DecodeRuneInString(tok)
+		if unicode.IsLetter(r) {
+			return fmt.Sprintf("<mi>%s</mi>", html.EscapeString(tok)), nil
+		}
+		return fmt.Sprintf("<mo>%s</mo>", html.EscapeString(tok)), nil
+	}
+	return p.parseCommand(tok)
+}
+
+// parseCommand parses the command tok and its arguments
+func (p *texParser) parseCommand(tok string) (string, error) {
+	name := tok[1:]
+	if s, ok := texGreek[name]; ok {
+		if unicode.IsUpper([]rune(s)[0]) {
+			return fmt.Sprintf(`<mi mathvariant="normal">%s</mi>`, s), nil
+		}
+		return fmt.Sprintf("<mi>%s</mi>", s), nil
+	}
+	if s, ok := texOperators[name]; ok {
+		return fmt.Sprintf("<mo>%s</mo>", html.EscapeString(s)), nil
+	}
+	if s, ok := texLargeOperators[name]; ok {
+		return fmt.Sprintf("<mo>%s</mo>", s), nil
+	}
+	if texFunctions[name] {
+		return fmt.Sprintf("<mi>%s</mi>", name), nil
+	}
+	if width, ok := texSpaces[name]; ok {
+		return fmt.Sprintf(`<mspace width="%s"/>`, width), nil
+	}
+	if accent, ok := texAccents[name]; ok {
+		arg, err := p.parseArg()
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf(`<mover accent="true">%s<mo>%s</mo></mover>`, arg, accent), nil
+	}
+	if variant, ok := texFonts[name]; ok {
+		arg, err := p.parseArg()
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf(`<mstyle mathvariant="%s">%s</mstyle>`, variant, arg), nil
+	}
+
+	switch name {
+	case "frac", "dfrac", "tfrac", "binom":
+		num, err := p.parseArg()
+		if err != nil {
+			return "", err
+		}
+		den, err := p.parseArg()
+		if err != nil {
+			return "", err
+		}
+		if name == "binom" {
+			return fmt.Sprintf(`<mrow><mo>(</mo><mfrac linethickness="0">%s%s</mfrac><mo>)</mo></mrow>`, num, den), nil
+		}
+		return fmt.Sprintf("<mfrac>%s%s</mfrac>", num, den), nil
+	case "sqrt":
+		index := ""
+		if p.peek() == "[" {
+			p.next()
+			p.optDepth++
+			nodes, err := p.parseRow()
+			p.optDepth--
+			if err != nil {
+				return "", err
+			}
+			if err := p.expect("]"); err != nil {
+				return "", err
+			}
+			index = mrow(nodes)
+		}
+		arg, err := p.parseArg()
+		if err != nil {
+			return "", err
+		}
+		if index != "" {
+			return fmt.Sprintf("<mroot>%s%s</mroot>", arg, index), nil
+		}
+		return fmt.Sprintf("<msqrt>%s</msqrt>", arg), nil
+	case "text", "textrm", "mbox", "operatorname":
+		text, err := p.rawGroup()
+		if err != nil {
+			return "", err
+		}
+		if name == "operatorname" {
+			return fmt.Sprintf("<mi>%s</mi>", html.EscapeString(text)), nil
+		}
+		return fmt.Sprintf("<mtext>%s</mtext>", html.EscapeString(text)), nil
+	case "left":
+		open := p.fence()
+		nodes, err := p.parseRow()
+		if err != nil {
+			return "", err
+		}
+		if err := p.expect(`\right`); err != nil {
+			return "", err
+		}
+		return fenced(open, p.fence(), nodes), nil
+	case "begin":
+		return p.parseEnvironment()
+	case "\\":
+		return "", nil
+	}
+	if len(name) == 1 && !isASCIILetter(name[0]) {
+		// escaped characters like \% or \$
+		return fmt.Sprintf("<mo>%s</mo>", html.EscapeString(name)), nil
+	}
+	return fmt.Sprintf("<merror><mtext>%s</mtext></merror>", html.EscapeString(tok)), nil
+}
+
+// fence returns the delimiter following \left or \right, "." is the empty delimiter
+func (p *texParser) fence() string {
+	tok := p.next()
+	if s, ok := texOperators[strings.TrimPrefix(tok, `\`)]; ok && strings.HasPrefix(tok, `\`) {
+		return s
+	}
+	if tok == "." {
+		return ""
+	}
+	return tok
+}
+
+// parseEnvironment parses the body of \begin{name}...\end{name} into a table
+func (p *texParser) parseEnvironment() (string, error) {
+	name, err := p.rawGroup()
+	if err != nil {
+		return "", err
+	}
+	if name == "array" && p.peek() == "{" {
+		// the column specification
+		if _, err := p.rawGroup(); err != nil {
+			return "", err
+		}
+	}
+
+	p.envDepth++
+	defer func() { p.envDepth-- }()
+
+	rows := []string{}
+	cells := []string{}
+	for {
+		nodes, err := p.parseRow()
+		if err != nil {
+			return "", err
+		}
+		cells = append(cells, "<mtd>"+mrow(nodes)+"</mtd>")
+
+		switch tok := p.next(); tok {
+		case "&":
+			continue
+		case `\\`:
+			rows = append(rows, "<mtr>"+strings.Join(cells, "")+"</mtr>")
+			cells = []string{}
+			continue
+		case `\end`:
+			end, err := p.rawGroup()
+			if err != nil {
+				return "", err
+			}
+			if end != name {
+				return "", fmt.Errorf(`math: \begin{%s} ended by \end{%s}`, name, end)
+			}
+		case "":
+			return "", errMathEnd
+		default:
+			return "", fmt.Errorf("math: unexpected %s at offset %d", tok, p.pos-len(tok))
+		}
+		break
+	}
+	if len(cells) > 1 || cells[0] != "<mtd><mrow></mrow></mtd>" {
+		rows = append(rows, "<mtr>"+strings.Join(cells, "")+"</mtr>")
+	}
+
+	table := "<mtable>" + strings.Join(rows, "") + "</mtable>"
+	if name == "cases" || name == "aligned" || name == "align" {
+		table = `<mtable columnalign="left">` + strings.Join(rows, "") + "</mtable>"
+	}
+	fences := texMatrixFences[name]
+	if fences[0] == "" && fences[1] == "" {
+		return table, nil
+	}
+	return fenced(fences[0], fences[1], []string{table}), nil
+}
+
+// mrow groups nodes, a single node is returned as is
+func mrow(nodes []string) string {
+	if len(nodes) == 1 {
+		return nodes[0]
+	}
+	return "<mrow>" + strings.Join(nodes, "") + "</mrow>"
+}
+
+// fenced surrounds nodes with stretchy open and close fences
+func fenced(open, close string, nodes []string) string {
+	sb := strings.Builder{}
+	sb.WriteString("<mrow>")
+	if open != "" {
+		sb.WriteString(fmt.Sprintf(`<mo fence="true">%s</mo>`, html.EscapeString(open)))
+	}
+	sb.WriteString(strings.Join(nodes, ""))
+	if close != "" {
+		sb.WriteString(fmt.Sprintf(`<mo fence="true">%s</mo>`, html.EscapeString(close)))
+	}
+	sb.WriteString("</mrow>")
+	return sb.String()
+}
+
+func isASCIILetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
@@ -0,0 +1,106 @@
+package goeditorjs_test
+
+import (
+	"errors"
+	"html"
+	"testing"
+
+	"github.com/davidscottmills/goeditorjs"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_MathHandler_Type(t *testing.T) {
+	h := &goeditorjs.MathHandler{}
+	require.Equal(t, "math", h.Type())
+}
+
+func Test_MathHandler_Returns_Parse_Err(t *testing.T) {
+	h := &goeditorjs.MathHandler{}
+	_, err := h.GenerateHTML(goeditorjs.EditorJSBlock{Type: "math", Data: []byte{}})
+	require.Error(t, err)
+	_, err = h.GenerateMarkdown(goeditorjs.EditorJSBlock{Type: "math", Data: []byte{}})
+	require.Error(t, err)
+}
+
+func Test_MathHandler_GenerateHTML(t *testing.T) {
+	h := &goeditorjs.MathHandler{}
+	result, err := h.GenerateHTML(goeditorjs.EditorJSBlock{Type: "math", Data: []byte(`{"text": " a < \\frac{1}{2} "}`)})
+	require.NoError(t, err)
+	require.Equal(t, `<div class="math">\[a &lt; \frac{1}{2}\]</div>`, result)
+
+	h = &goeditorjs.MathHandler{Options: &goeditorjs.MathHandlerOptions{Renderer: &goeditorjs.MathMLRenderer{}}}
+	result, err = h.GenerateHTML(goeditorjs.EditorJSBlock{Type: "math", Data: []byte(`{"text": "x^2"}`)})
+	require.NoError(t, err)
+	require.Equal(t, `<div class="math"><math xmlns="http://www.w3.org/1998/Math/MathML" display="block"><semantics>`+
+		`<msup><mi>x</mi><mn>2</mn></msup><annotation encoding="application/x-tex">x^2</annotation></semantics></math></div>`, result)
+
+	h = &goeditorjs.MathHandler{Options: &goeditorjs.MathHandlerOptions{Renderer: goeditorjs.MathRendererFunc(func(tex string, display bool) (string, error) {
+		return "", errors.New("boom")
+	})}}
+	_, err = h.GenerateHTML(goeditorjs.EditorJSBlock{Type: "math", Data: []byte(`{"text": "x^2"}`)})
+	require.Error(t, err)
+}
+
+func Test_MathHandler_GenerateMarkdown(t *testing.T) {
+	h := &goeditorjs.MathHandler{}
+	result, err := h.GenerateMarkdown(goeditorjs.EditorJSBlock{Type: "math", Data: []byte(`{"text": "E = mc^2\n"}`)})
+	require.NoError(t, err)
+	require.Equal(t, "$$\nE = mc^2\n$$", result)
+}
+
+func Test_MathMLRenderer_RenderMath(t *testing.T) {
+	testData := []struct {
+		tex            string
+		expectedResult string
+	}{
+		{tex: `x_1^{n+1}`, expectedResult: `<msubsup><mi>x</mi><mn>1</mn><mrow><mi>n</mi><mo>+</mo><mn>1</mn></mrow></msubsup>`},
+		{tex: `\frac12 \le \sqrt[3]{y}`, expectedResult: `<mrow><mfrac><mn>1</mn><mn>2</mn></mfrac><mo>≤</mo><mroot><mi>y</mi><mn>3</mn></mroot></mrow>`},
+		{tex: `\sum_{i=1}^n i`, expectedResult: `<mrow><munderover><mo>∑</mo><mrow><mi>i</mi><mo>=</mo><mn>1</mn></mrow><mi>n</mi></munderover><mi>i</mi></mrow>`},
+		{tex: `\left( \alpha \right.`, expectedResult: `<mrow><mo fence="true">(</mo><mi>α</mi></mrow>`},
+		{tex: `\begin{pmatrix} a & b \\ c & d \end{pmatrix}`,
+			expectedResult: `<mrow><mo fence="true">(</mo><mtable><mtr><mtd><mi>a</mi></mtd><mtd><mi>b</mi></mtd></mtr>` +
+				`<mtr><mtd><mi>c</mi></mtd><mtd><mi>d</mi></mtd></mtr></mtable><mo fence="true">)</mo></mrow>`},
+		{tex: `\text{if } x \in [0, 1]`, expectedResult: `<mrow><mtext>if </mtext><mi>x</mi><mo>∈</mo><mo>[</mo><mn>0</mn><mo>,</mo><mn>1</mn><mo>]</mo></mrow>`},
+		{tex: `f' = \mathbb{R} \unknown`, expectedResult: `<mrow><msup><mi>f</mi><mo>′</mo></msup><mo>=</mo><mstyle mathvariant="double-struck"><mi>R</mi></mstyle><merror><mtext>\unknown</mtext></merror></mrow>`},
+	}
+
+	for _, td := range testData {
+		result, err := (&goeditorjs.MathMLRenderer{}).RenderMath(td.tex, true)
+		require.NoError(t, err)
+		require.Equal(t, `<math xmlns="http://www.w3.org/1998/Math/MathML" display="block"><semantics>`+td.expectedResult+
+			`<annotation encoding="application/x-tex">`+html.EscapeString(td.tex)+`</annotation></semantics></math>`, result)
+	}
+
+	for _, tex := range []string{`{x`, `x}`, `\frac{1}`, `\left( x`, `\begin{matrix} a \end{pmatrix}`} {
+		_, err := (&goeditorjs.MathMLRenderer{}).RenderMath(tex, false)
+		require.Error(t, err, tex)
+	}
+}
+
+func Test_HTMLEngine_InlineMath(t *testing.T) {
+	data := `{"blocks": [{"type": "paragraph", "data": {"text": "Energy <span class=\"inline-math\">E = mc^2</span>.", "alignment": "left"}}]}`
+
+	eng := goeditorjs.NewHTMLEngine()
+	eng.RegisterBlockHandlers(&goeditorjs.ParagraphHandler{})
+	result, err := eng.GenerateHTML(data)
+	require.NoError(t, err)
+	require.Equal(t, `<p>Energy <span class="math-inline">\(E = mc^2\)</span>.</p>`, result)
+
+	eng = goeditorjs.NewHTMLEngine(goeditorjs.WithMathRenderer(&goeditorjs.MathMLRenderer{}))
+	eng.RegisterBlockHandlers(&goeditorjs.ParagraphHandler{})
+	result, err = eng.GenerateHTML(data)
+	require.NoError(t, err)
+	require.Equal(t, `<p>Energy <span class="math-inline"><math xmlns="http://www.w3.org/1998/Math/MathML"><semantics><mrow><mi>E</mi><mo>=</mo>`+
+		`<mi>m</mi><msup><mi>c</mi><mn>2</mn></msup></mrow><annotation encoding="application/x-tex">E = mc^2</annotation></semantics></math></span>.</p>`, result)
+}
+
+func Test_MarkdownEngine_InlineMath(t *testing.T) {
+	eng := goeditorjs.NewMarkdownEngine()
+	eng.RegisterBlockHandlers(&goeditorjs.ParagraphHandler{}, &goeditorjs.HeaderHandler{})
+	result, err := eng.GenerateMarkdown(`{"blocks": [
+		{"type": "header", "data": {"text": "About <span class=\"inline-math\">\\pi</span>", "level": 2}},
+		{"type": "paragraph", "data": {"text": "<span class=\"inline-math\">a &lt; b</span> holds"}}
+	]}`)
+	require.NoError(t, err)
+	require.Equal(t, "## About $\\pi$\n\n$a < b$ holds", result)
+}
@@ -0,0 +1,197 @@
+package goeditorjs
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"path"
+	"strings"
+)
+
+// MediaKind is the kind of media rendered by a MediaHandler
+type MediaKind string
+
+const (
+	// MediaAudio renders audio elements
+	MediaAudio MediaKind = "audio"
+	// MediaVideo renders video elements
+	MediaVideo MediaKind = "video"
+)
+
+// mediaTypes maps file extensions to the MIME types of source elements
+var mediaTypes = map[string]string{
+	".mp4":  "video/mp4",
+	".m4v":  "video/mp4",
+	".webm": "video/webm",
+	".ogv":  "video/ogg",
+	".mov":  "video/quicktime",
+	".mp3":  "audio/mpeg",
+	".m4a":  "audio/mp4",
+	".aac":  "audio/aac",
+	".oga":  "audio/ogg",
+	".ogg":  "audio/ogg",
+	".opus": "audio/ogg",
+	".wav":  "audio/wav",
+	".flac": "audio/flac",
+}
+
+// MediaHandler is the default MediaHandler for EditorJS HTML and markdown generation of the audio and video blocks of
+// custom tools. Their data holds the url in "url" or "file.url", a "caption", a "poster" image for videos, the
+// "autoplay", "controls", "loop" and "muted" flags and text "tracks" with "src", "kind", "srclang" and "label".
+// Controls are shown unless disabled.
+type MediaHandler struct {
+	// Kind is the kind of media, MediaAudio or MediaVideo
+	Kind MediaKind
+	// BlockType is the block type handled, if not provided it is the Kind, i.e. "audio" or "video"
+	BlockType string
+	// Options are made available to the GenerateHTML and GenerateMarkdown functions.
+	// If not provided, DefaultMediaHandlerOptions will be used.
+	Options *MediaHandlerOptions
+	// Classes override the engine's ClassMap for the elements this handler emits. The keys are "audio", "video",
+	// "figure" and "figcaption".
+	Classes ClassMap
+}
+
+// MediaHandlerOptions are the options available to the MediaHandler
+type MediaHandlerOptions struct {
+	// Preload is the value of the preload attribute, e.g. "metadata"
+	Preload string
+	// RewriteURL rewrites the media, poster and track urls, e.g. StaticDomainRewriter("https://cdn.example.com")
+	RewriteURL URLRewriter
+}
+
+// DefaultMediaHandlerOptions are the default options available to the MediaHandler
+var DefaultMediaHandlerOptions = &MediaHandlerOptions{Preload: "metadata"}
+
+func (h *MediaHandler) parse(editorJSBlock EditorJSBlock) (*Media, error) {
+	if h.Options == nil {
+		h.Options = DefaultMediaHandlerOptions
+	}
+
+	media := &Media{}
+	if err := json.Unmarshal(editorJSBlock.Data, media); err != nil {
+		return nil, err
+	}
+	if media.URL == "" {
+		media.URL = media.File.URL
+	}
+	media.URL = h.Options.RewriteURL.rewrite(media.URL)
+	media.Poster = h.Options.RewriteURL.rewrite(media.Poster)
+	for i := range media.Tracks {
+		media.Tracks[i].Src = h.Options.RewriteURL.rewrite(media.Tracks[i].Src)
+	}
+	return media, nil
+}
+
+// kind returns the kind of media, MediaVideo if none is set
+func (h *MediaHandler) kind() MediaKind {
+	if h.Kind == MediaAudio {
+		return MediaAudio
+	}
+	return MediaVideo
+}
+
+// Type returns BlockType or the Kind
+func (h *MediaHandler) Type() string {
+	if h.BlockType != "" {
+		return h.BlockType
+	}
+	return string(h.kind())
+}
+
+// GenerateHTML generates html for MediaBlocks
+func (h *MediaHandler) GenerateHTML(editorJSBlock EditorJSBlock) (string, error) {
+	return h.GenerateHTMLWithEngine(editorJSBlock, nil)
+}
+
+// GenerateHTMLWithEngine generates html for MediaBlocks using the engine's ClassMap
+func (h *MediaHandler) GenerateHTMLWithEngine(editorJSBlock EditorJSBlock, engine *HTMLEngine) (string, error) {
+	media, err := h.parse(editorJSBlock)
+	if err != nil {
+		return "", err
+	}
+
+	kind := h.kind()
+	attrs := []string{}
+	if media.Controls == nil || *media.Controls {
+		attrs = append(attrs, "controls")
+	}
+	if media.Autoplay {
+		attrs = append(attrs, "autoplay")
+	}
+	if media.Muted {
+		attrs = append(attrs, "muted")
+	}
+	if media.Loop {
+		attrs = append(attrs, "loop")
+	}
+	if h.Options.Preload != "" {
+		attrs = append(attrs, fmt.Sprintf(`preload="%s"`, html.EscapeString(h.Options.Preload)))
+	}
+	if media.Poster != "" && kind == MediaVideo {
+		attrs = append(attrs, fmt.Sprintf(`poster="%s"`, html.EscapeString(media.Poster)))
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("<%s%s", kind, classAttr(h.Classes, engine, string(kind))))
+	for _, attr := range attrs {
+		sb.WriteString(" " + attr)
+	}
+	sb.WriteString(">")
+	source := fmt.Sprintf(`<source src="%s"`, html.EscapeString(media.URL))
+	if mediaType, ok := mediaTypes[strings.ToLower(path.Ext(urlPath(media.URL)))]; ok {
+		source += fmt.Sprintf(` type="%s"`, mediaType)
+	}
+	sb.WriteString(source + ">")
+	for _, track := range media.Tracks {
+		kind := track.Kind
+		if kind == "" {
+			kind = "captions"
+		}
+		sb.WriteString(fmt.Sprintf(`<track kind="%s" src="%s"`, html.EscapeString(kind), html.EscapeString(track.Src)))
+		if track.SrcLang != "" {
+			sb.WriteString(fmt.Sprintf(` srclang="%s"`, html.EscapeString(track.SrcLang)))
+		}
+		if track.Label != "" {
+			sb.WriteString(fmt.Sprintf(` label="%s"`, html.EscapeString(track.Label)))
+		}
+		sb.WriteString(">")
+	}
+	// fallback for browsers without media support
+	sb.WriteString(fmt.Sprintf(`<a%s>%s</a></%s>`, hrefAttr(media.URL), html.EscapeString(h.title(media)), kind))
+
+	if strings.TrimSpace(media.Caption) == "" {
+		return sb.String(), nil
+	}
+	figcaption := fmt.Sprintf(`<figcaption%s>%s</figcaption>`, classAttr(h.Classes, engine, "figcaption"), media.Caption)
+	return fmt.Sprintf(`<figure%s>%s%s</figure>`, classAttr(h.Classes, engine, "figure"), sb.String(), figcaption), nil
+}
+
+// GenerateMarkdown generates markdown for MediaBlocks. Markdown can't embed media, so a link to the media is
+// generated, showing the poster of videos if there is one.
+func (h *MediaHandler) GenerateMarkdown(editorJSBlock EditorJSBlock) (string, error) {
+	media, err := h.parse(editorJSBlock)
+	if err != nil {
+		return "", err
+	}
+
+	title := escapeMarkdown(h.title(media))
+	if media.Poster != "" && h.kind() == MediaVideo {
+		title = fmt.Sprintf("![%s](%s)", title, markdownURL(media.Poster))
+	}
+	return markdownLink(title, media.URL), nil
+}
+
+// title returns the plain text caption of media or, without caption, the file name of its url or the kind of media
+func (h *MediaHandler) title(media *Media) string {
+	if title := strings.TrimSpace(plainText(media.Caption)); title != "" {
+		return title
+	}
+	if name := urlFileName(media.URL); name != "" {
+		return name
+	}
+	if h.kind() == MediaAudio {
+		return "Audio"
+	}
+	return "Video"
+}
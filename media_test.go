@@ -0,0 +1,72 @@
+package goeditorjs_test
+
+import (
+	"testing"
+
+	"github.com/davidscottmills/goeditorjs"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_MediaHandler_Type(t *testing.T) {
+	require.Equal(t, "audio", (&goeditorjs.MediaHandler{Kind: goeditorjs.MediaAudio}).Type())
+	require.Equal(t, "video", (&goeditorjs.MediaHandler{Kind: goeditorjs.MediaVideo}).Type())
+	require.Equal(t, "video", (&goeditorjs.MediaHandler{}).Type())
+	require.Equal(t, "hostedVideo", (&goeditorjs.MediaHandler{Kind: goeditorjs.MediaVideo, BlockType: "hostedVideo"}).Type())
+}
+
+func Test_MediaHandler_Returns_Parse_Err(t *testing.T) {
+	h := &goeditorjs.MediaHandler{}
+	_, err := h.GenerateHTML(goeditorjs.EditorJSBlock{Type: "video", Data: []byte{}})
+	require.Error(t, err)
+	_, err = h.GenerateMarkdown(goeditorjs.EditorJSBlock{Type: "video", Data: []byte{}})
+	require.Error(t, err)
+}
+
+func Test_MediaHandler_GenerateHTML(t *testing.T) {
+	testData := []struct {
+		kind           goeditorjs.MediaKind
+		data           string
+		expectedResult string
+	}{
+		{kind: goeditorjs.MediaVideo,
+			data: `{"url": "/v/intro.MP4?x=1", "caption": "The <b>intro</b>", "poster": "/v/intro.jpg", "autoplay": true, "muted": true, "loop": true,
+				"tracks": [{"src": "/v/intro.vtt", "srclang": "en", "label": "English"}]}`,
+			expectedResult: `<figure><video controls autoplay muted loop preload="metadata" poster="/v/intro.jpg">` +
+				`<source src="/v/intro.MP4?x=1" type="video/mp4"><track kind="captions" src="/v/intro.vtt" srclang="en" label="English">` +
+				`<a href="/v/intro.MP4?x=1">The intro</a></video><figcaption>The <b>intro</b></figcaption></figure>`},
+		{kind: goeditorjs.MediaAudio,
+			data:           `{"file": {"url": "/a/talk.mp3"}, "controls": false, "poster": "/ignored.jpg"}`,
+			expectedResult: `<audio preload="metadata"><source src="/a/talk.mp3" type="audio/mpeg"><a href="/a/talk.mp3">talk.mp3</a></audio>`},
+		{kind: goeditorjs.MediaVideo,
+			data:           `{"url": "https://example.com/stream"}`,
+			expectedResult: `<video controls preload="metadata"><source src="https://example.com/stream"><a href="https://example.com/stream">stream</a></video>`},
+		{kind: goeditorjs.MediaVideo,
+			data:           `{"url": "javascript:alert(1)"}`,
+			expectedResult: `<video controls preload="metadata"><source src="javascript:alert(1)"><a>Video</a></video>`},
+	}
+
+	for _, td := range testData {
+		h := &goeditorjs.MediaHandler{Kind: td.kind}
+		result, err := h.GenerateHTML(goeditorjs.EditorJSBlock{Type: string(td.kind), Data: []byte(td.data)})
+		require.NoError(t, err)
+		require.Equal(t, td.expectedResult, result)
+	}
+}
+
+func Test_MediaHandler_GenerateMarkdown(t *testing.T) {
+	h := &goeditorjs.MediaHandler{Kind: goeditorjs.MediaVideo, Options: &goeditorjs.MediaHandlerOptions{
+		RewriteURL: goeditorjs.StaticDomainRewriter("https://cdn.example.com"),
+	}}
+	result, err := h.GenerateMarkdown(goeditorjs.EditorJSBlock{Type: "video", Data: []byte(`{"url": "/intro.mp4", "caption": "Intro [1]", "poster": "/intro.jpg"}`)})
+	require.NoError(t, err)
+	require.Equal(t, `[![Intro \[1\]](https://cdn.example.com/intro.jpg)](https://cdn.example.com/intro.mp4)`, result)
+
+	h = &goeditorjs.MediaHandler{Kind: goeditorjs.MediaAudio}
+	result, err = h.GenerateMarkdown(goeditorjs.EditorJSBlock{Type: "audio", Data: []byte(`{"url": "/talk.mp3", "poster": "/talk.jpg"}`)})
+	require.NoError(t, err)
+	require.Equal(t, `[talk.mp3](/talk.mp3)`, result)
+
+	result, err = h.GenerateMarkdown(goeditorjs.EditorJSBlock{Type: "audio", Data: []byte(`{"url": "javascript:alert(1)"}`)})
+	require.NoError(t, err)
+	require.Equal(t, `Audio`, result)
+}
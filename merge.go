@@ -0,0 +1,261 @@
+package goeditorjs
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// MergeConflictKind is the kind of a conflict of a three-way merge
+type MergeConflictKind string
+
+const (
+	// MergeConflictModified blocks were modified differently on both sides
+	MergeConflictModified MergeConflictKind = "modified"
+	// MergeConflictDeleted blocks were modified on one side and deleted on the other
+	MergeConflictDeleted MergeConflictKind = "deleted"
+	// MergeConflictAdded blocks were added on both sides with the same id, but different content
+	MergeConflictAdded MergeConflictKind = "added"
+)
+
+// MergeConflict is a block Merge couldn't merge. The merged document holds our version of the block, or no block if
+// we deleted it.
+type MergeConflict struct {
+	Kind MergeConflictKind
+	// Base is the block in the common ancestor, nil for MergeConflictAdded
+	Base *EditorJSBlock
+	// Ours is our version of the block, nil if we deleted it
+	Ours *EditorJSBlock
+	// Theirs is their version of the block, nil if they deleted it
+	Theirs *EditorJSBlock
+	// Index is the index of the block in the merged document, -1 if it isn't part of it
+	Index int
+}
+
+// MergeResult is the result of Merge
+type MergeResult struct {
+	// Document is the merged document. It has the time and version of ours.
+	Document *Document
+	// Conflicts are the blocks that couldn't be merged, in the order of the merged document
+	Conflicts []MergeConflict
+}
+
+// mergeKey identifies a block across the versions of a three-way merge: by its index in base, or by its index in
+// the version that added it
+type mergeKey struct {
+	side  byte
+	index int
+}
+
+// mergeEntry is a block of the merged document
+type mergeEntry struct {
+	key   mergeKey
+	block EditorJSBlock
+}
+
+// Merge merges the changes ours and theirs made to base. Blocks are aligned like Diff does, by their ids or, if they
+// have none, by their similarity. Insertions, deletions and data changes of either side are merged, as well as moves
+// of blocks that only one side moved. Data changes are merged field by field, blocks whose fields were changed
+// differently on both sides, or that were modified on one side and deleted on the other, are conflicts.
+func Merge(base, ours, theirs *Document) *MergeResult {
+	oursMatches := alignBlocks(base.Blocks, ours.Blocks, DefaultDiffOptions.Similarity)
+	theirsMatches := alignBlocks(base.Blocks, theirs.Blocks, DefaultDiffOptions.Similarity)
+	oursMoved := movedBlocks(oursMatches, len(ours.Blocks))
+	theirsMoved := movedBlocks(theirsMatches, len(theirs.Blocks))
+
+	inOurs, inTheirs := make([]int, len(base.Blocks)), make([]int, len(base.Blocks))
+	for bi := range base.Blocks {
+		inOurs[bi], inTheirs[bi] = -1, -1
+	}
+	movedByUs := make([]bool, len(base.Blocks))
+	for oi, bi := range oursMatches {
+		if bi >= 0 {
+			inOurs[bi], movedByUs[bi] = oi, oursMoved[oi]
+		}
+	}
+	for ti, bi := range theirsMatches {
+		if bi >= 0 {
+			inTheirs[bi] = ti
+		}
+	}
+
+	result := &MergeResult{Document: &Document{Time: ours.Time, Version: ours.Version}}
+	// conflictKeys are the keys of the blocks of result.Conflicts
+	conflictKeys := []mergeKey{}
+	addConflict := func(key mergeKey, conflict MergeConflict) {
+		result.Conflicts = append(result.Conflicts, conflict)
+		conflictKeys = append(conflictKeys, key)
+	}
+
+	// our blocks in our order, merged with their changes
+	merged := []mergeEntry{}
+	for oi, bi := range oursMatches {
+		if bi < 0 {
+			merged = append(merged, mergeEntry{key: mergeKey{'o', oi}, block: ours.Blocks[oi]})
+			continue
+		}
+		key := mergeKey{'b', bi}
+		if ti := inTheirs[bi]; ti >= 0 {
+			block, ok := mergeBlock(base.Blocks[bi], ours.Blocks[oi], theirs.Blocks[ti])
+			if !ok {
+				addConflict(key, MergeConflict{Kind: MergeConflictModified, Base: &base.Blocks[bi],
+					Ours: &ours.Blocks[oi], Theirs: &theirs.Blocks[ti]})
+			}
+			merged = append(merged, mergeEntry{key: key, block: block})
+			continue
+		}
+		if !equalBlocks(base.Blocks[bi], ours.Blocks[oi]) {
+			addConflict(key, MergeConflict{Kind: MergeConflictDeleted, Base: &base.Blocks[bi], Ours: &ours.Blocks[oi]})
+			merged = append(merged, mergeEntry{key: key, block: ours.Blocks[oi]})
+		}
+	}
+	// the blocks we deleted, which they modified
+	for bi, oi := range inOurs {
+		if ti := inTheirs[bi]; oi < 0 && ti >= 0 && !equalBlocks(base.Blocks[bi], theirs.Blocks[ti]) {
+			addConflict(mergeKey{'b', bi}, MergeConflict{Kind: MergeConflictDeleted, Base: &base.Blocks[bi],
+				Theirs: &theirs.Blocks[ti]})
+		}
+	}
+
+	// their insertions and the moves only they made, in their order, each after the block preceding it in theirs
+	after := -1
+	for ti, bi := range theirsMatches {
+		key := mergeKey{'b', bi}
+		place := false
+		if bi < 0 {
+			key, place = mergeKey{'t', ti}, true
+			if oi := addedBlock(ours, oursMatches, theirs.Blocks[ti]); oi >= 0 {
+				if !equalBlocks(ours.Blocks[oi], theirs.Blocks[ti]) {
+					addConflict(mergeKey{'o', oi}, MergeConflict{Kind: MergeConflictAdded, Ours: &ours.Blocks[oi],
+						Theirs: &theirs.Blocks[ti]})
+				}
+				key, place = mergeKey{'o', oi}, false
+			}
+		} else {
+			place = theirsMoved[ti] && !movedByUs[bi]
+		}
+
+		index := mergeIndex(merged, key)
+		if place {
+			entry := mergeEntry{key: key, block: theirs.Blocks[ti]}
+			if index >= 0 {
+				entry = merged[index]
+				merged = append(merged[:index], merged[index+1:]...)
+				if index <= after {
+					after--
+				}
+			}
+			if bi < 0 || index >= 0 {
+				merged = append(merged[:after+1], append([]mergeEntry{entry}, merged[after+1:]...)...)
+				index = after + 1
+			}
+		}
+		if index >= 0 {
+			after = index
+		}
+	}
+
+	for _, entry := range merged {
+		result.Document.Blocks = append(result.Document.Blocks, entry.block)
+	}
+	for i, key := range conflictKeys {
+		result.Conflicts[i].Index = mergeIndex(merged, key)
+	}
+	sort.SliceStable(result.Conflicts, func(i, j int) bool {
+		a, b := result.Conflicts[i].Index, result.Conflicts[j].Index
+		return a >= 0 && (b < 0 || a < b)
+	})
+	return result
+}
+
+// mergeIndex returns the index of the block identified by key in merged, or -1
+func mergeIndex(merged []mergeEntry, key mergeKey) int {
+	for i, entry := range merged {
+		if entry.key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// addedBlock returns the index of the block we added with the id of block, or -1
+func addedBlock(ours *Document, oursMatches []int, block EditorJSBlock) int {
+	if block.ID == "" {
+		return -1
+	}
+	for oi, bi := range oursMatches {
+		if bi < 0 && ours.Blocks[oi].ID == block.ID {
+			return oi
+		}
+	}
+	return -1
+}
+
+// mergeBlock merges the changes of ours and theirs to the block base. It returns ours and false if both changed the
+// type of the block or the same field of its data or tunes differently.
+func mergeBlock(base, ours, theirs EditorJSBlock) (EditorJSBlock, bool) {
+	switch {
+	case equalBlocks(base, ours):
+		return theirs, true
+	case equalBlocks(base, theirs), equalBlocks(ours, theirs):
+		return ours, true
+	case ours.Type != theirs.Type:
+		return ours, false
+	}
+
+	var baseData, oursData, theirsData map[string]json.RawMessage
+	if json.Unmarshal(base.Data, &baseData) != nil || json.Unmarshal(ours.Data, &oursData) != nil ||
+		json.Unmarshal(theirs.Data, &theirsData) != nil {
+		return ours, false
+	}
+	data, ok := mergeFields(baseData, oursData, theirsData)
+	if !ok {
+		return ours, false
+	}
+	tunes, ok := mergeFields(base.Tunes, ours.Tunes, theirs.Tunes)
+	if !ok {
+		return ours, false
+	}
+
+	merged := ours
+	var err error
+	if merged.Data, err = json.Marshal(data); err != nil {
+		return ours, false
+	}
+	merged.Tunes = tunes
+	if len(tunes) == 0 {
+		merged.Tunes = nil
+	}
+	return merged, true
+}
+
+// mergeFields merges the changes of ours and theirs to the fields of base. A missing field is deleted. It returns
+// false if a field was changed differently on both sides.
+func mergeFields(base, ours, theirs map[string]json.RawMessage) (map[string]json.RawMessage, bool) {
+	equal := func(a, b map[string]json.RawMessage, key string) bool {
+		valueA, okA := a[key]
+		valueB, okB := b[key]
+		return okA == okB && (!okA || equalJSON(valueA, valueB))
+	}
+
+	keys := map[string]bool{}
+	for _, fields := range []map[string]json.RawMessage{base, ours, theirs} {
+		for key := range fields {
+			keys[key] = true
+		}
+	}
+	merged := map[string]json.RawMessage{}
+	for key := range keys {
+		source := ours
+		switch {
+		case equal(base, ours, key):
+			source = theirs
+		case equal(base, theirs, key), equal(ours, theirs, key):
+		default:
+			return nil, false
+		}
+		if value, ok := source[key]; ok {
+			merged[key] = value
+		}
+	}
+	return merged, true
+}
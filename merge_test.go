@@ -0,0 +1,129 @@
+package goeditorjs_test
+
+import (
+	"testing"
+
+	"github.com/davidscottmills/goeditorjs"
+	"github.com/stretchr/testify/require"
+)
+
+func mergeBlockTexts(t *testing.T, doc *goeditorjs.Document) []string {
+	texts := []string{}
+	for _, block := range doc.Blocks {
+		texts = append(texts, block.ID+":"+string(block.Data))
+	}
+	return texts
+}
+
+func Test_Merge(t *testing.T) {
+	base := parseDocument(t, `{"blocks": [
+		{"id": "a", "type": "header", "data": {"text": "Title", "level": 1}},
+		{"id": "b", "type": "paragraph", "data": {"text": "B"}},
+		{"id": "c", "type": "paragraph", "data": {"text": "C"}},
+		{"id": "d", "type": "paragraph", "data": {"text": "D"}},
+		{"id": "e", "type": "paragraph", "data": {"text": "E"}}
+	]}`)
+	ours := parseDocument(t, `{"time": 2, "blocks": [
+		{"id": "a", "type": "header", "data": {"text": "New title", "level": 1}},
+		{"id": "b", "type": "paragraph", "data": {"text": "B"}},
+		{"id": "x", "type": "paragraph", "data": {"text": "X"}},
+		{"id": "c", "type": "paragraph", "data": {"text": "C"}},
+		{"id": "e", "type": "paragraph", "data": {"text": "E"}}
+	]}`)
+	theirs := parseDocument(t, `{"time": 3, "blocks": [
+		{"id": "a", "type": "header", "data": {"text": "Title", "level": 2}},
+		{"id": "e", "type": "paragraph", "data": {"text": "E"}},
+		{"id": "b", "type": "paragraph", "data": {"text": "B"}},
+		{"id": "c", "type": "paragraph", "data": {"text": "C2"}},
+		{"id": "y", "type": "paragraph", "data": {"text": "Y"}},
+		{"id": "d", "type": "paragraph", "data": {"text": "D"}}
+	]}`)
+
+	result := goeditorjs.Merge(base, ours, theirs)
+	require.Empty(t, result.Conflicts)
+	require.Equal(t, int64(2), result.Document.Time)
+	require.Equal(t, []string{
+		`a:{"level":2,"text":"New title"}`,
+		`e:{"text": "E"}`,
+		`b:{"text": "B"}`,
+		`x:{"text": "X"}`,
+		`c:{"text": "C2"}`,
+		`y:{"text": "Y"}`,
+	}, mergeBlockTexts(t, result.Document))
+}
+
+func Test_Merge_Conflicts(t *testing.T) {
+	base := parseDocument(t, `{"blocks": [
+		{"id": "a", "type": "paragraph", "data": {"text": "A"}},
+		{"id": "b", "type": "paragraph", "data": {"text": "B"}},
+		{"id": "c", "type": "paragraph", "data": {"text": "C"}},
+		{"id": "d", "type": "paragraph", "data": {"text": "D"}}
+	]}`)
+	ours := parseDocument(t, `{"blocks": [
+		{"id": "a", "type": "paragraph", "data": {"text": "A1"}},
+		{"id": "b", "type": "paragraph", "data": {"text": "B1"}},
+		{"id": "d", "type": "paragraph", "data": {"text": "D"}},
+		{"id": "x", "type": "paragraph", "data": {"text": "X1"}},
+		{"id": "z", "type": "paragraph", "data": {"text": "Z"}}
+	]}`)
+	theirs := parseDocument(t, `{"blocks": [
+		{"id": "a", "type": "paragraph", "data": {"text": "A2"}},
+		{"id": "c", "type": "paragraph", "data": {"text": "C2"}},
+		{"id": "d", "type": "paragraph", "data": {"text": "D"}},
+		{"id": "x", "type": "paragraph", "data": {"text": "X2"}},
+		{"id": "z", "type": "paragraph", "data": {"text": "Z"}}
+	]}`)
+
+	result := goeditorjs.Merge(base, ours, theirs)
+	require.Equal(t, []string{
+		`a:{"text": "A1"}`,
+		`b:{"text": "B1"}`,
+		`d:{"text": "D"}`,
+		`x:{"text": "X1"}`,
+		`z:{"text": "Z"}`,
+	}, mergeBlockTexts(t, result.Document))
+
+	type conflict struct {
+		kind               goeditorjs.MergeConflictKind
+		base, ours, theirs string
+		index              int
+	}
+	id := func(block *goeditorjs.EditorJSBlock) string {
+		if block == nil {
+			return ""
+		}
+		return block.ID
+	}
+	conflicts := []conflict{}
+	for _, c := range result.Conflicts {
+		conflicts = append(conflicts, conflict{c.Kind, id(c.Base), id(c.Ours), id(c.Theirs), c.Index})
+	}
+	require.Equal(t, []conflict{
+		{goeditorjs.MergeConflictModified, "a", "a", "a", 0},
+		{goeditorjs.MergeConflictDeleted, "b", "b", "", 1},
+		{goeditorjs.MergeConflictAdded, "", "x", "x", 3},
+		{goeditorjs.MergeConflictDeleted, "c", "", "c", -1},
+	}, conflicts)
+}
+
+func Test_Merge_Fields(t *testing.T) {
+	base := parseDocument(t, `{"blocks": [{"type": "image", "data": {"file": {"url": "a.png"}, "caption": "A", "stretched": false},
+		"tunes": {"footnotes": ["x"]}}]}`)
+	ours := parseDocument(t, `{"blocks": [{"type": "image", "data": {"file": {"url": "a.png"}, "caption": "B", "stretched": false},
+		"tunes": {"footnotes": ["x"]}}]}`)
+	theirs := parseDocument(t, `{"blocks": [{"type": "image", "data": {"file": {"url": "a.png"}, "caption": "A", "stretched": true}}]}`)
+
+	result := goeditorjs.Merge(base, ours, theirs)
+	require.Empty(t, result.Conflicts)
+	require.Len(t, result.Document.Blocks, 1)
+	require.JSONEq(t, `{"file": {"url": "a.png"}, "caption": "B", "stretched": true}`, string(result.Document.Blocks[0].Data))
+	require.Nil(t, result.Document.Blocks[0].Tunes)
+
+	// blocks changed this much are only aligned by their ids
+	base.Blocks[0].ID, ours.Blocks[0].ID, theirs.Blocks[0].ID = "a", "a", "a"
+	theirs.Blocks[0].Data = []byte(`{"file": {"url": "a.png"}, "caption": "C", "stretched": true}`)
+	result = goeditorjs.Merge(base, ours, theirs)
+	require.Len(t, result.Conflicts, 1)
+	require.Equal(t, goeditorjs.MergeConflictModified, result.Conflicts[0].Kind)
+	require.Equal(t, ours.Blocks[0], result.Document.Blocks[0])
+}
@@ -0,0 +1,110 @@
+package goeditorjs
+
+import (
+	"encoding/json"
+	"strings"
+	"unicode/utf8"
+)
+
+// StatsOptions are the options available to Document.Stats
+type StatsOptions struct {
+	// WordsPerMinute is the reading speed ReadingTime is calculated with, 200 if not set
+	WordsPerMinute int
+	// ImageTypes are the types of the blocks counted as images. If nil, "image" and "simpleImage" are used.
+	ImageTypes []string
+}
+
+// DefaultStatsOptions are the default options available to Document.Stats
+var DefaultStatsOptions = &StatsOptions{WordsPerMinute: 200}
+
+// Stats are the statistics of a document. The blocks of nested documents, like the columns of editorjs-columns, are
+// included.
+type Stats struct {
+	// Words is the number of words of the text blocks. Every CJK character counts as a word.
+	Words int
+	// Characters is the number of characters of the text blocks, not counting whitespace
+	Characters int
+	// CharactersWithSpaces is the number of characters of the text blocks, counting runs of whitespace as one space
+	CharactersWithSpaces int
+	// ReadingTime is the reading time in minutes, rounded up
+	ReadingTime int
+	// Blocks is the number of blocks by type
+	Blocks map[string]int
+	// Headings is the number of header blocks
+	Headings int
+	// OutlineDepth is the nesting depth of the outline the headers make, e.g. 2 for h1, h2, h3, h2 and 3 for h1, h2,
+	// h3. Skipped levels don't count, so it is 2 for h1, h3 as well.
+	OutlineDepth int
+	// Links is the number of links in the text blocks and link tool blocks
+	Links int
+	// Images is the number of image blocks
+	Images int
+}
+
+// Stats returns the statistics of the document. If options is nil, DefaultStatsOptions will be used.
+func (d *Document) Stats(options *StatsOptions) *Stats {
+	if options == nil {
+		options = DefaultStatsOptions
+	}
+	imageTypes := options.ImageTypes
+	if imageTypes == nil {
+		imageTypes = []string{"image", "simpleImage"}
+	}
+
+	stats := &Stats{Blocks: map[string]int{}}
+	stats.add(d.Blocks, imageTypes, []int{})
+
+	if stats.Words > 0 {
+		wpm := options.WordsPerMinute
+		if wpm <= 0 {
+			wpm = 200
+		}
+		stats.ReadingTime = (stats.Words + wpm - 1) / wpm
+	}
+	return stats
+}
+
+// add adds the statistics of blocks. outline holds the levels of the headers the following headers are nested in.
+func (stats *Stats) add(blocks []EditorJSBlock, imageTypes []string, outline []int) {
+	for _, block := range blocks {
+		stats.Blocks[block.Type]++
+
+		text := blockText(block)
+		stats.Words += countWords(text)
+		fields := strings.Fields(text)
+		stats.CharactersWithSpaces += utf8.RuneCountInString(strings.Join(fields, " "))
+		stats.Characters += utf8.RuneCountInString(strings.Join(fields, ""))
+		for _, text := range blockTexts(block) {
+			stats.Links += len(hrefRegexp.FindAllString(text, -1))
+		}
+
+		switch {
+		case containsString(imageTypes, block.Type):
+			stats.Images++
+		case block.Type == "linkTool":
+			stats.Links++
+		case block.Type == "header":
+			h := &Header{}
+			if json.Unmarshal(block.Data, h) != nil {
+				break
+			}
+			stats.Headings++
+			for len(outline) > 0 && outline[len(outline)-1] >= h.Level {
+				outline = outline[:len(outline)-1]
+			}
+			outline = append(outline, h.Level)
+			if len(outline) > stats.OutlineDepth {
+				stats.OutlineDepth = len(outline)
+			}
+		case block.Type == "columns":
+			columns := &Columns{}
+			if json.Unmarshal(block.Data, columns) != nil {
+				break
+			}
+			for _, col := range columns.Cols {
+				// headers in a column are nested in the headers preceding the columns, not in other columns
+				stats.add(col.Blocks, imageTypes, append([]int{}, outline...))
+			}
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package goeditorjs_test
+
+import (
+	"testing"
+
+	"github.com/davidscottmills/goeditorjs"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Document_Stats(t *testing.T) {
+	doc, err := goeditorjs.ParseDocument(`{"blocks": [
+		{"type": "header", "data": {"text": "Go 入门", "level": 1}},
+		{"type": "paragraph", "data": {"text": "Read <a href=\"https://go.dev\">the docs</a> — 每天&nbsp;学习。"}},
+		{"type": "header", "data": {"text": "Setup", "level": 3}},
+		{"type": "code", "data": {"code": "go run ."}},
+		{"type": "image", "data": {"file": {"url": "https://example.com/a.png"}}},
+		{"type": "linkTool", "data": {"link": "https://example.com"}},
+		{"type": "columns", "data": {"cols": [
+			{"blocks": [{"type": "header", "data": {"text": "Left", "level": 4}}, {"type": "simpleImage", "data": {"url": "b.png"}}]},
+			{"blocks": [{"type": "list", "data": {"style": "unordered", "items": ["one<br>two", "<a href='x'>three</a>"]}}]}
+		]}}
+	]}`)
+	require.NoError(t, err)
+
+	stats := doc.Stats(nil)
+	require.Equal(t, &goeditorjs.Stats{
+		// Go 入 门, Read the docs 每 天 学 习, Setup, Left, one two three
+		Words:                15,
+		Characters:           41,
+		CharactersWithSpaces: 49,
+		ReadingTime:          1,
+		Blocks: map[string]int{"header": 3, "paragraph": 1, "code": 1, "image": 1, "linkTool": 1, "columns": 1,
+			"simpleImage": 1, "list": 1},
+		Headings:     3,
+		OutlineDepth: 3,
+		Links:        3,
+		Images:       2,
+	}, stats)
+
+	stats = doc.Stats(&goeditorjs.StatsOptions{WordsPerMinute: 5, ImageTypes: []string{"image"}})
+	require.Equal(t, 3, stats.ReadingTime)
+	require.Equal(t, 1, stats.Images)
+}
+
+func Test_Document_Stats_OutlineDepth(t *testing.T) {
+	testData := []struct {
+		levels   string
+		expected int
+	}{
+		{levels: "", expected: 0},
+		{levels: "1", expected: 1},
+		{levels: "1,2,3,2", expected: 3},
+		{levels: "1,3", expected: 2},
+		{levels: "2,2,2", expected: 1},
+		{levels: "3,1,2", expected: 2},
+	}
+	for _, td := range testData {
+		blocks := ""
+		for i, level := range td.levels {
+			if level == ',' {
+				continue
+			}
+			if i > 0 {
+				blocks += ","
+			}
+			blocks += `{"type": "header", "data": {"text": "h", "level": ` + string(level) + `}}`
+		}
+		doc, err := goeditorjs.ParseDocument(`{"blocks": [` + blocks + `]}`)
+		require.NoError(t, err)
+		require.Equal(t, td.expected, doc.Stats(nil).OutlineDepth, td.levels)
+	}
+}
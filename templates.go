@@ -0,0 +1,329 @@
+package goeditorjs
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"math"
+	"path"
+	"sort"
+	"strings"
+)
+
+//go:embed templates/*.html
+var defaultTemplatesFS embed.FS
+
+// HTMLTemplates holds the named html/templates used by TemplateHandlers. A block is rendered by the template named
+// after its type. Templates whose name starts with "_" are treated as partials and don't render a block type.
+type HTMLTemplates struct {
+	tmpl *template.Template
+}
+
+// templateFuncs are the functions available to all templates
+var templateFuncs = template.FuncMap{
+	"join": strings.Join,
+	// safeHTML marks the inline html of EditorJS text as safe
+	"safeHTML": func(text interface{}) template.HTML {
+		s, _ := text.(string)
+		return template.HTML(s)
+	},
+}
+
+// NewHTMLTemplates creates HTMLTemplates with the templates of the built-in block types loaded.
+// The defaults can be found in the templates directory of this package.
+func NewHTMLTemplates() *HTMLTemplates {
+	t := &HTMLTemplates{tmpl: template.New("").Funcs(templateFuncs)}
+	if err := t.ParseFS(defaultTemplatesFS, "templates/*.html"); err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// Funcs adds functions to the templates. It must be called before the templates using them are parsed.
+func (t *HTMLTemplates) Funcs(funcs template.FuncMap) *HTMLTemplates {
+	t.tmpl.Funcs(funcs)
+	return t
+}
+
+// Parse registers or overrides the template for blockType
+func (t *HTMLTemplates) Parse(blockType, text string) error {
+	_, err := t.tmpl.New(blockType).Parse(text)
+	return err
+}
+
+// ParseFS registers or overrides templates from the files in fsys matching patterns, e.g. an embed.FS.
+// The template of each file is named after the file name without its extension, so "header.html" overrides the
+// template of header blocks.
+func (t *HTMLTemplates) ParseFS(fsys fs.FS, patterns ...string) error {
+	for _, pattern := range patterns {
+		files, err := fs.Glob(fsys, pattern)
+		if err != nil {
+			return err
+		}
+		if len(files) == 0 {
+			return fmt.Errorf("pattern matches no files: %#q", pattern)
+		}
+		for _, file := range files {
+			content, err := fs.ReadFile(fsys, file)
+			if err != nil {
+				return err
+			}
+			name := strings.TrimSuffix(path.Base(file), path.Ext(file))
+			if err := t.Parse(name, strings.TrimSuffix(string(content), "\n")); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Handlers returns a TemplateHandler for every block type that has a template
+func (t *HTMLTemplates) Handlers() []HTMLBlockHandler {
+	names := []string{}
+	for _, tmpl := range t.tmpl.Templates() {
+		if name := tmpl.Name(); name != "" && !strings.HasPrefix(name, "_") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	handlers := make([]HTMLBlockHandler, len(names))
+	for i, name := range names {
+		handlers[i] = &TemplateHandler{BlockType: name, Templates: t}
+	}
+	return handlers
+}
+
+// execute renders the template named name with data
+func (t *HTMLTemplates) execute(name string, data interface{}) (string, error) {
+	tmpl := t.tmpl.Lookup(name)
+	if tmpl == nil {
+		return "", fmt.Errorf("no template defined for block type %s", name)
+	}
+	sb := strings.Builder{}
+	if err := tmpl.Execute(&sb, data); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// TemplateHandler is an HTMLBlockHandler rendering blocks of BlockType with the template of the same name.
+// The built-in block types are decoded into a view with their inline html marked safe, all other block types are
+// decoded into a map[string]interface{} whose inline html can be marked safe with the safeHTML function.
+// All other values are escaped by html/template. The classes of the elements, resolved from Classes and the engine's
+// ClassMap, are available to the templates as the map .Class, e.g. {{.Class.p}}.
+type TemplateHandler struct {
+	BlockType string
+	// Templates are the templates to render with. If not provided, NewHTMLTemplates will be used.
+	Templates *HTMLTemplates
+	// Classes override the engine's ClassMap for the elements the templates emit
+	Classes ClassMap
+}
+
+// Type returns BlockType
+func (h *TemplateHandler) Type() string {
+	return h.BlockType
+}
+
+// GenerateHTML generates html for blocks of BlockType
+func (h *TemplateHandler) GenerateHTML(editorJSBlock EditorJSBlock) (string, error) {
+	return h.GenerateHTMLWithEngine(editorJSBlock, nil)
+}
+
+// GenerateHTMLWithEngine generates html for blocks of BlockType using the engine's ClassMap
+func (h *TemplateHandler) GenerateHTMLWithEngine(editorJSBlock EditorJSBlock, engine *HTMLEngine) (string, error) {
+	if h.Templates == nil {
+		h.Templates = NewHTMLTemplates()
+	}
+
+	view, err := templateView(editorJSBlock, templateClasses(h.Classes, engine))
+	if err != nil {
+		return "", err
+	}
+	return h.Templates.execute(h.BlockType, view)
+}
+
+// templateClasses returns the classes of the elements, taking them from the handler's classes first and the engine's
+// ClassMap second
+func templateClasses(handlerClasses ClassMap, engine *HTMLEngine) map[string]string {
+	classes := map[string]string{}
+	if engine != nil {
+		for element, class := range engine.ClassMap {
+			classes[element] = class
+		}
+	}
+	for element, class := range handlerClasses {
+		classes[element] = class
+	}
+	return classes
+}
+
+type headerView struct {
+	Class map[string]string
+	Text  template.HTML
+	Level int
+}
+
+type paragraphView struct {
+	Class     map[string]string
+	Text      template.HTML
+	Alignment string
+}
+
+type listView struct {
+	Class   map[string]string
+	Style   string
+	Ordered bool
+	Items   []template.HTML
+}
+
+type quoteView struct {
+	Class     map[string]string
+	Text      template.HTML
+	Caption   template.HTML
+	Alignment string
+}
+
+type codeView struct {
+	Class    map[string]string
+	Code     string
+	Language string
+}
+
+type rawView struct {
+	Class map[string]string
+	HTML  template.HTML
+}
+
+type imageView struct {
+	Class          map[string]string
+	URL            string
+	Caption        template.HTML
+	Alt            string
+	WithBorder     bool
+	WithBackground bool
+	Stretched      bool
+	Width          int
+	Height         int
+	// Classes are the DefaultImageHandlerOptions classes matching the flags above, followed by the class of img
+	Classes []string
+}
+
+type tableView struct {
+	Class        map[string]string
+	WithHeadings bool
+	Rows         [][]template.HTML
+}
+
+// templateView decodes the block data into the value its template is executed with. class holds the classes of the
+// elements, which other block types get as the "Class" entry of their data.
+func templateView(editorJSBlock EditorJSBlock, class map[string]string) (interface{}, error) {
+	switch editorJSBlock.Type {
+	case "header":
+		header, err := (&HeaderHandler{}).parse(editorJSBlock)
+		if err != nil {
+			return nil, err
+		}
+		return &headerView{Class: class, Text: template.HTML(header.Text), Level: header.Level}, nil
+	case "paragraph":
+		paragraph, err := (&ParagraphHandler{}).parse(editorJSBlock)
+		if err != nil {
+			return nil, err
+		}
+		return &paragraphView{Class: class, Text: template.HTML(paragraph.Text), Alignment: paragraph.Alignment}, nil
+	case "list":
+		list, err := (&ListHandler{}).parse(editorJSBlock)
+		if err != nil {
+			return nil, err
+		}
+		view := &listView{Class: class, Style: list.Style, Ordered: list.Style == "ordered"}
+		for _, item := range list.Items {
+			view.Items = append(view.Items, template.HTML(item))
+		}
+		return view, nil
+	case "quote":
+		quote, err := (&QuoteHandler{}).parse(editorJSBlock)
+		if err != nil {
+			return nil, err
+		}
+		return &quoteView{Class: class, Text: template.HTML(quote.Text), Caption: template.HTML(quote.Caption), Alignment: quote.Alignment}, nil
+	case "codeBox", "code":
+		codeBox, err := (&CodeBoxHandler{}).parse(editorJSBlock)
+		if err != nil {
+			return nil, err
+		}
+		language, _ := parseCodeInfo(codeBox.Language)
+		view := &codeView{Class: class, Code: codeBox.Code, Language: language}
+		if editorJSBlock.Type == "codeBox" {
+			view.Code = codeBoxText(codeBox.Code)
+		}
+		return view, nil
+	case "raw":
+		raw := &Raw{}
+		if err := json.Unmarshal(editorJSBlock.Data, raw); err != nil {
+			return nil, err
+		}
+		return &rawView{Class: class, HTML: template.HTML(raw.HTML)}, nil
+	case "image", "simpleImage":
+		image, err := (&ImageHandler{}).parse(editorJSBlock)
+		if err != nil {
+			return nil, err
+		}
+		view := &imageView{
+			Class:          class,
+			URL:            image.File.URL,
+			Caption:        template.HTML(image.Caption),
+			Alt:            plainText(image.Caption),
+			WithBorder:     image.WithBorder,
+			WithBackground: image.WithBackground,
+			Stretched:      image.Stretched,
+			Width:          int(math.Round(image.File.Width)),
+			Height:         int(math.Round(image.File.Height)),
+		}
+		if image.Stretched {
+			view.Classes = append(view.Classes, DefaultImageHandlerOptions.StretchClass)
+		}
+		if image.WithBorder {
+			view.Classes = append(view.Classes, DefaultImageHandlerOptions.BorderClass)
+		}
+		if image.WithBackground {
+			view.Classes = append(view.Classes, DefaultImageHandlerOptions.BackgroundClass)
+		}
+		if c := class["img"]; c != "" {
+			view.Classes = append(view.Classes, c)
+		}
+		return view, nil
+	case "table":
+		table, err := (&TableHandler{}).parse(editorJSBlock)
+		if err != nil {
+			return nil, err
+		}
+		view := &tableView{Class: class, WithHeadings: table.WithHeadings}
+		for _, row := range table.Content {
+			cells := make([]template.HTML, len(row))
+			for i, cell := range row {
+				cells[i] = template.HTML(cell)
+			}
+			view.Rows = append(view.Rows, cells)
+		}
+		return view, nil
+	}
+
+	data := map[string]interface{}{}
+	if err := json.Unmarshal(editorJSBlock.Data, &data); err != nil {
+		return nil, err
+	}
+	if editorJSBlock.Type == "alert" {
+		// the type is rendered as class name and the alignment as style, so both are limited to the known values
+		if kind, _ := data["type"].(string); !alertTypes[kind] {
+			data["type"] = "info"
+		}
+		if align, _ := data["align"].(string); !alertAligns[align] {
+			data["align"] = "left"
+		}
+	}
+	data["Class"] = class
+	return data, nil
+}
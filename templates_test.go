@@ -0,0 +1,130 @@
+package goeditorjs_test
+
+import (
+	"embed"
+	"testing"
+	"testing/fstest"
+
+	"github.com/davidscottmills/goeditorjs"
+	"github.com/stretchr/testify/require"
+)
+
+//go:embed testdata/templates
+var testTemplatesFS embed.FS
+
+func Test_TemplateHandler_Type(t *testing.T) {
+	h := &goeditorjs.TemplateHandler{BlockType: "header"}
+	require.Equal(t, "header", h.Type())
+}
+
+func Test_TemplateHandler_GenerateHTML_Returns_Parse_Err(t *testing.T) {
+	h := &goeditorjs.TemplateHandler{BlockType: "header"}
+	_, err := h.GenerateHTML(goeditorjs.EditorJSBlock{Type: "header", Data: []byte{}})
+	require.Error(t, err)
+}
+
+func Test_TemplateHandler_GenerateHTML_Defaults(t *testing.T) {
+	templates := goeditorjs.NewHTMLTemplates()
+	testData := []struct {
+		blockType      string
+		data           string
+		expectedResult string
+	}{
+		{blockType: "header", data: `{"text": "Heading <b>bold</b>","level": 2}`, expectedResult: "<h2>Heading <b>bold</b></h2>"},
+		{blockType: "paragraph", data: `{"text": "paragraph","alignment": "left"}`, expectedResult: "<p>paragraph</p>"},
+		{blockType: "paragraph", data: `{"text": "paragraph","alignment": "center"}`, expectedResult: `<p style="text-align:center">paragraph</p>`},
+		{blockType: "list", data: `{"style": "ordered", "items": ["one", "two"]}`, expectedResult: "<ol><li>one</li><li>two</li></ol>"},
+		{blockType: "list", data: `{"style": "unordered", "items": ["one", "two"]}`, expectedResult: "<ul><li>one</li><li>two</li></ul>"},
+		{blockType: "code", data: `{"language": "go\"", "code": "a < b"}`, expectedResult: `<pre><code class="go&#34;">a &lt; b</code></pre>`},
+		{blockType: "code", data: `{"language": "go {1-2}", "code": "a"}`, expectedResult: `<pre><code class="go">a</code></pre>`},
+		{blockType: "codeBox", data: `{"language": "js", "code": "<span>a &amp;&lt; b</span>"}`, expectedResult: `<pre><code class="javascript">a &amp;&lt; b</code></pre>`},
+		{blockType: "raw", data: `{"html": "<div>raw</div>"}`, expectedResult: `<div>raw</div>`},
+		{blockType: "quote", data: `{"text": "q", "caption": "c"}`, expectedResult: `<blockquote>q<cite>c</cite></blockquote>`},
+		{blockType: "warning", data: `{"title": "T", "message": "<b>m</b>"}`, expectedResult: `<aside class="warning" role="note"><p class="warning__title">T</p><p class="warning__message"><b>m</b></p></aside>`},
+		{blockType: "alert", data: `{"type": "", "align": "right", "message": "m"}`, expectedResult: `<div class="alert alert--info" style="text-align:right" role="alert">m</div>`},
+		{blockType: "alert", data: `{"type": "x onclick", "align": "center;color:red", "message": "m"}`, expectedResult: `<div class="alert alert--info" role="alert">m</div>`},
+		{blockType: "alert", data: `{"type": ["danger"], "message": "m"}`, expectedResult: `<div class="alert alert--info" role="alert">m</div>`},
+		{blockType: "delimiter", data: `{}`, expectedResult: `<hr/>`},
+		{blockType: "image", data: `{"file":{"url": "javascript:alert(1)"},"caption": "A <b>cat</b>","stretched": true,"withBorder": true}`,
+			expectedResult: `<img src="#ZgotmplZ" alt="A cat" class="image-tool--stretched image-tool--withBorder"/>`},
+		{blockType: "table", data: `{"withHeadings": true, "content": [["a", "b"], ["1", "2"]]}`,
+			expectedResult: `<table><tr><th>a</th><th>b</th></tr><tr><td>1</td><td>2</td></tr></table>`},
+	}
+
+	for _, td := range testData {
+		h := &goeditorjs.TemplateHandler{BlockType: td.blockType, Templates: templates}
+		result, err := h.GenerateHTML(goeditorjs.EditorJSBlock{Type: td.blockType, Data: []byte(td.data)})
+		require.NoError(t, err)
+		require.Equal(t, td.expectedResult, result)
+	}
+}
+
+func Test_TemplateHandler_GenerateHTML_ClassMap(t *testing.T) {
+	eng := goeditorjs.NewHTMLEngine(goeditorjs.WithClassMap(goeditorjs.ClassMap{
+		"h2": "title", "p": "text", "ul": "list", "li": "item", "pre": "pre", "code": "code", "hr": "hr",
+		"img": "picture", "table": "table", "tr": "row", "th": "head", "td": "cell", "blockquote": "quote",
+		"cite": "caption", "warning": "note", "warning__message": "note__message", "alert": "box",
+	}))
+	handlers := goeditorjs.NewHTMLTemplates().Handlers()
+	for _, h := range handlers {
+		if h.Type() == "paragraph" {
+			h.(*goeditorjs.TemplateHandler).Classes = goeditorjs.ClassMap{"p": "override"}
+		}
+	}
+	eng.RegisterBlockHandlers(handlers...)
+	result, err := eng.GenerateHTML(`{"blocks": [
+		{"type": "header", "data": {"text": "Title", "level": 2}},
+		{"type": "paragraph", "data": {"text": "Text", "alignment": "center"}},
+		{"type": "list", "data": {"style": "unordered", "items": ["a"]}},
+		{"type": "code", "data": {"language": "go", "code": "x"}},
+		{"type": "delimiter", "data": {}},
+		{"type": "image", "data": {"file": {"url": "/a.png"}, "caption": "", "withBorder": true}},
+		{"type": "table", "data": {"withHeadings": true, "content": [["h"], ["c"]]}},
+		{"type": "quote", "data": {"text": "q", "caption": "c"}},
+		{"type": "warning", "data": {"title": "", "message": "m"}},
+		{"type": "alert", "data": {"type": "danger", "message": "m"}}
+	]}`)
+	require.NoError(t, err)
+	require.Equal(t, `<h2 class="title">Title</h2>`+
+		`<p class="override" style="text-align:center">Text</p>`+
+		`<ul class="list"><li class="item">a</li></ul>`+
+		`<pre class="pre"><code class="go code">x</code></pre>`+
+		`<hr class="hr"/>`+
+		`<img src="/a.png" alt="" class="image-tool--withBorder picture"/>`+
+		`<table class="table"><tr class="row"><th class="head">h</th></tr><tr class="row"><td class="cell">c</td></tr></table>`+
+		`<blockquote class="quote">q<cite class="caption">c</cite></blockquote>`+
+		`<aside class="warning note" role="note"><p class="warning__message note__message">m</p></aside>`+
+		`<div class="alert alert--danger box" role="alert">m</div>`, result)
+}
+
+func Test_HTMLTemplates_Parse_Custom_Type(t *testing.T) {
+	templates := goeditorjs.NewHTMLTemplates()
+	require.NoError(t, templates.Parse("warning", `<aside title="{{.title}}">{{.message}}</aside>`))
+	h := &goeditorjs.TemplateHandler{BlockType: "warning", Templates: templates}
+	result, err := h.GenerateHTML(goeditorjs.EditorJSBlock{Type: "warning", Data: []byte(`{"title": "a\"b", "message": "<b>x</b>"}`)})
+	require.NoError(t, err)
+	require.Equal(t, `<aside title="a&#34;b">&lt;b&gt;x&lt;/b&gt;</aside>`, result)
+}
+
+func Test_HTMLTemplates_ParseFS(t *testing.T) {
+	templates := goeditorjs.NewHTMLTemplates()
+	fsys := fstest.MapFS{"paragraph.tmpl": {Data: []byte("<div class=\"p\">{{.Text}}</div>\n")}}
+	require.NoError(t, templates.ParseFS(fsys, "*.tmpl"))
+	require.NoError(t, templates.ParseFS(testTemplatesFS, "testdata/templates/*.html"))
+	require.Error(t, templates.ParseFS(fsys, "*.missing"))
+
+	eng := goeditorjs.NewHTMLEngine()
+	eng.RegisterBlockHandlers(templates.Handlers()...)
+	result, err := eng.GenerateHTML(`{"blocks": [
+		{"type": "header", "data": {"text": "Title", "level": 1}},
+		{"type": "paragraph", "data": {"text": "Text"}}
+	]}`)
+	require.NoError(t, err)
+	require.Equal(t, `<h1 class="title">Title</h1><div class="p">Text</div>`, result)
+}
+
+func Test_TemplateHandler_GenerateHTML_Missing_Template(t *testing.T) {
+	h := &goeditorjs.TemplateHandler{BlockType: "missing"}
+	_, err := h.GenerateHTML(goeditorjs.EditorJSBlock{Type: "missing", Data: []byte(`{}`)})
+	require.Error(t, err)
+}
@@ -0,0 +1,127 @@
+package goeditorjs
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ToggleHandler is the default ToggleHandler for EditorJS HTML and markdown generation of the collapsible blocks of
+// the editorjs-toggle-block tool. The toggle owns the blocks following it that are linked to it by their parentId, or
+// else the number of blocks following it given by its items, which the engines render inside of it.
+type ToggleHandler struct {
+	// Classes override the engine's ClassMap for the elements this handler emits. The keys are "details" and "summary".
+	Classes ClassMap
+}
+
+func (*ToggleHandler) parse(editorJSBlock EditorJSBlock) (*Toggle, error) {
+	toggle := &Toggle{}
+	return toggle, json.Unmarshal(editorJSBlock.Data, toggle)
+}
+
+// Type "toggle"
+func (*ToggleHandler) Type() string {
+	return "toggle"
+}
+
+// OwnedBlockCount returns the number of following blocks linked to the toggle, or the items of the toggle if the block
+// following it isn't linked to it. A block is linked to the toggle if the parentId of its data is the fk of the toggle,
+// or its id if it has no fk. The blocks linked to the toggles linked to it are owned as well.
+func (h *ToggleHandler) OwnedBlockCount(editorJSBlock EditorJSBlock, following []EditorJSBlock) int {
+	toggle, err := h.parse(editorJSBlock)
+	if err != nil {
+		return 0
+	}
+	if count := linkedBlockCount(toggleKey(editorJSBlock, toggle), following); count > 0 {
+		return count
+	}
+	return toggle.Items
+}
+
+// toggleKey returns the key the blocks owned by the toggle are linked to it with
+func toggleKey(editorJSBlock EditorJSBlock, toggle *Toggle) string {
+	if toggle.FK != "" {
+		return toggle.FK
+	}
+	return editorJSBlock.ID
+}
+
+// linkedBlockCount returns the number of blocks at the start of following that are linked to key, directly or through
+// the toggles linked to it
+func linkedBlockCount(key string, following []EditorJSBlock) int {
+	if key == "" {
+		return 0
+	}
+	keys := map[string]bool{key: true}
+	for i, block := range following {
+		data := &struct {
+			ParentID string `json:"parentId"`
+		}{}
+		if json.Unmarshal(block.Data, data) != nil || !keys[data.ParentID] {
+			return i
+		}
+		if block.Type == "toggle" {
+			if toggle, err := (&ToggleHandler{}).parse(block); err == nil {
+				if key := toggleKey(block, toggle); key != "" {
+					keys[key] = true
+				}
+			}
+		}
+	}
+	return len(following)
+}
+
+// GenerateHTML generates html for ToggleBlocks without their items
+func (h *ToggleHandler) GenerateHTML(editorJSBlock EditorJSBlock) (string, error) {
+	return h.GenerateHTMLWithChildren(editorJSBlock, "", nil)
+}
+
+// GenerateHTMLWithChildren generates html for ToggleBlocks with the html of their items
+func (h *ToggleHandler) GenerateHTMLWithChildren(editorJSBlock EditorJSBlock, children string, engine *HTMLEngine) (string, error) {
+	toggle, err := h.parse(editorJSBlock)
+	if err != nil {
+		return "", err
+	}
+
+	open := ""
+	if toggle.Status != "closed" {
+		open = " open"
+	}
+	return fmt.Sprintf("<details%s%s><summary%s>%s</summary>%s</details>",
+		classAttr(h.Classes, engine, "details"), open, classAttr(h.Classes, engine, "summary"), toggle.Text, children), nil
+}
+
+// GenerateMarkdown generates markdown for ToggleBlocks without their items
+func (h *ToggleHandler) GenerateMarkdown(editorJSBlock EditorJSBlock) (string, error) {
+	return h.GenerateMarkdownWithChildren(editorJSBlock, "", nil)
+}
+
+// GenerateMarkdownWithChildren generates markdown for ToggleBlocks with the markdown of their items. Markdown has no
+// collapsible blocks, so the html details element is used, which GitHub and most other renderers support. The items
+// are separated by blank lines so they are still parsed as markdown. Dialects without html get the text in bold
+// followed by the items.
+func (h *ToggleHandler) GenerateMarkdownWithChildren(editorJSBlock EditorJSBlock, children string, engine *MarkdownEngine) (string, error) {
+	toggle, err := h.parse(editorJSBlock)
+	if err != nil {
+		return "", err
+	}
+
+	if !engine.dialect().HTML {
+		lines := []string{"**" + strings.TrimSpace(markdownInline(toggle.Text, engine.dialect())) + "**"}
+		if children != "" {
+			lines = append(lines, children)
+		}
+		return strings.Join(lines, "\n\n"), nil
+	}
+
+	details := "<details>"
+	if toggle.Status != "closed" {
+		details = "<details open>"
+	}
+	lines := []string{details, fmt.Sprintf("<summary>%s</summary>", strings.TrimSpace(toggle.Text))}
+	if children != "" {
+		lines = append(lines, "", children, "")
+	}
+	lines = append(lines, "</details>")
+	return strings.Join(lines, "\n"), nil
+}
@@ -0,0 +1,149 @@
+package goeditorjs_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/davidscottmills/goeditorjs"
+	"github.com/stretchr/testify/require"
+)
+
+const toggleData = `{"blocks": [
+	{"id": "t1", "type": "toggle", "data": {"text": "Details", "status": "open", "items": 3}},
+	{"type": "paragraph", "data": {"text": "One", "alignment": "left"}},
+	{"id": "t2", "type": "toggle", "data": {"text": "Inner", "status": "closed", "items": 1}},
+	{"type": "paragraph", "data": {"text": "Two", "alignment": "left"}},
+	{"type": "paragraph", "data": {"text": "After", "alignment": "left"}},
+	{"type": "toggle", "data": {"text": "Last", "items": 5}}
+]}`
+
+func Test_ToggleHandler_Type(t *testing.T) {
+	h := &goeditorjs.ToggleHandler{}
+	require.Equal(t, "toggle", h.Type())
+}
+
+func Test_ToggleHandler_Returns_Parse_Err(t *testing.T) {
+	h := &goeditorjs.ToggleHandler{}
+	_, err := h.GenerateHTML(goeditorjs.EditorJSBlock{Type: "toggle", Data: []byte{}})
+	require.Error(t, err)
+	_, err = h.GenerateMarkdown(goeditorjs.EditorJSBlock{Type: "toggle", Data: []byte{}})
+	require.Error(t, err)
+	require.Equal(t, 0, h.OwnedBlockCount(goeditorjs.EditorJSBlock{Type: "toggle", Data: []byte{}}, nil))
+}
+
+func Test_ToggleHandler_GenerateHTML(t *testing.T) {
+	h := &goeditorjs.ToggleHandler{}
+	result, err := h.GenerateHTML(goeditorjs.EditorJSBlock{Type: "toggle", Data: []byte(`{"text": "Details", "status": "closed", "items": 1}`)})
+	require.NoError(t, err)
+	require.Equal(t, `<details><summary>Details</summary></details>`, result)
+
+	eng := goeditorjs.NewHTMLEngine()
+	eng.RegisterBlockHandlers(&goeditorjs.ToggleHandler{}, &goeditorjs.ParagraphHandler{})
+	result, err = eng.GenerateHTML(toggleData)
+	require.NoError(t, err)
+	require.Equal(t, `<details open><summary>Details</summary><p>One</p>`+
+		`<details><summary>Inner</summary><p>Two</p></details></details>`+
+		`<p>After</p><details open><summary>Last</summary></details>`, result)
+}
+
+func Test_ToggleHandler_GenerateMarkdown(t *testing.T) {
+	eng := goeditorjs.NewMarkdownEngine()
+	eng.RegisterBlockHandlers(&goeditorjs.ToggleHandler{}, &goeditorjs.ParagraphHandler{})
+	result, err := eng.GenerateMarkdown(toggleData)
+	require.NoError(t, err)
+	require.Equal(t, "<details open>\n<summary>Details</summary>\n\nOne\n\n"+
+		"<details>\n<summary>Inner</summary>\n\nTwo\n\n</details>\n\n</details>\n\n"+
+		"After\n\n<details open>\n<summary>Last</summary>\n</details>", result)
+}
+
+// idOwner owns the run of following blocks whose ids start with the id of the block
+type idOwner struct {
+	goeditorjs.ToggleHandler
+}
+
+func (*idOwner) Type() string {
+	return "group"
+}
+
+func (*idOwner) OwnedBlockCount(block goeditorjs.EditorJSBlock, following []goeditorjs.EditorJSBlock) int {
+	count := 0
+	for count < len(following) && len(following[count].ID) > len(block.ID) && following[count].ID[:len(block.ID)] == block.ID {
+		count++
+	}
+	return count
+}
+
+func Test_HTMLEngine_BlockOwner_Unknown_Blocks(t *testing.T) {
+	data := `{"blocks": [
+		{"id": "g", "type": "group", "data": {"text": "Group"}},
+		{"id": "g1", "type": "paragraph", "data": {"text": "One", "alignment": "left"}},
+		{"id": "g2", "type": "missing", "data": {}},
+		{"id": "x", "type": "paragraph", "data": {"text": "Two", "alignment": "left"}}
+	]}`
+
+	eng := goeditorjs.NewHTMLEngine()
+	eng.RegisterBlockHandlers(&idOwner{}, &goeditorjs.ParagraphHandler{})
+	result, err := eng.GenerateHTMLWithUnknownBlock(data)
+	require.NoError(t, err)
+	require.Equal(t, "<details open><summary>Group</summary><p>One</p><pre><code>// type: missing</code><code>{}</code></pre></details><p>Two</p>", result)
+
+	_, err = eng.GenerateHTML(data)
+	require.True(t, errors.Is(err, goeditorjs.ErrBlockHandlerNotFound))
+}
+
+func Test_ToggleHandler_Linked_Blocks(t *testing.T) {
+	data := `{"blocks": [
+		{"id": "t1", "type": "toggle", "data": {"text": "Details", "status": "open", "fk": "k1", "items": 0}},
+		{"type": "paragraph", "data": {"text": "One", "alignment": "left", "parentId": "k1"}},
+		{"id": "t2", "type": "toggle", "data": {"text": "Inner", "status": "closed", "parentId": "k1"}},
+		{"type": "paragraph", "data": {"text": "Two", "alignment": "left", "parentId": "t2"}},
+		{"type": "paragraph", "data": {"text": "Three", "alignment": "left", "parentId": "k1"}},
+		{"type": "paragraph", "data": {"text": "After", "alignment": "left"}}
+	]}`
+
+	h := &goeditorjs.ToggleHandler{}
+	doc := parseDocument(t, data)
+	require.Equal(t, 4, h.OwnedBlockCount(doc.Blocks[0], doc.Blocks[1:]))
+	require.Equal(t, 1, h.OwnedBlockCount(doc.Blocks[2], doc.Blocks[3:]))
+
+	eng := goeditorjs.NewHTMLEngine()
+	eng.RegisterBlockHandlers(&goeditorjs.ToggleHandler{}, &goeditorjs.ParagraphHandler{})
+	result, err := eng.GenerateHTML(data)
+	require.NoError(t, err)
+	require.Equal(t, `<details open><summary>Details</summary><p>One</p>`+
+		`<details><summary>Inner</summary><p>Two</p></details><p>Three</p></details><p>After</p>`, result)
+}
+
+// failingOwner owns blocks like idOwner, but fails to render them
+type failingOwner struct {
+	idOwner
+}
+
+func (*failingOwner) GenerateHTMLWithChildren(goeditorjs.EditorJSBlock, string, *goeditorjs.HTMLEngine) (string, error) {
+	return "", errors.New("failed")
+}
+
+func (*failingOwner) GenerateMarkdownWithChildren(goeditorjs.EditorJSBlock, string, *goeditorjs.MarkdownEngine) (string, error) {
+	return "", errors.New("failed")
+}
+
+func Test_Engines_Failing_BlockOwner_Unknown_Blocks(t *testing.T) {
+	data := `{"blocks": [
+		{"id": "g", "type": "group", "data": {"text": "Group"}},
+		{"id": "g1", "type": "paragraph", "data": {"text": "One", "alignment": "left"}},
+		{"id": "x", "type": "paragraph", "data": {"text": "Two", "alignment": "left"}}
+	]}`
+
+	htmlEngine := goeditorjs.NewHTMLEngine()
+	htmlEngine.RegisterBlockHandlers(&failingOwner{}, &goeditorjs.ParagraphHandler{})
+	result, err := htmlEngine.GenerateHTMLWithUnknownBlock(data)
+	require.NoError(t, err)
+	require.Equal(t, "<pre><code>// type: group</code><code>{\n  \"text\": \"Group\"\n}</code></pre><p>Two</p>", result)
+
+	markdownEngine := goeditorjs.NewMarkdownEngine()
+	markdownEngine.RegisterBlockHandlers(&failingOwner{}, &goeditorjs.ParagraphHandler{})
+	result, err = markdownEngine.GenerateMarkdownWithUnknownBlock(data)
+	require.NoError(t, err)
+	require.NotContains(t, result, "One")
+	require.Contains(t, result, "Two")
+}
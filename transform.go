@@ -0,0 +1,256 @@
+package goeditorjs
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// Transformer transforms the blocks of a document, e.g. to clean them up before they are rendered. Transform must
+// not modify the blocks it is given, but return new ones.
+type Transformer interface {
+	Transform(blocks []EditorJSBlock) ([]EditorJSBlock, error)
+}
+
+// TransformerFunc is a function used as Transformer
+type TransformerFunc func(blocks []EditorJSBlock) ([]EditorJSBlock, error)
+
+// Transform calls f(blocks)
+func (f TransformerFunc) Transform(blocks []EditorJSBlock) ([]EditorJSBlock, error) {
+	return f(blocks)
+}
+
+// Pipeline is a Transformer running its transformers one after another
+type Pipeline []Transformer
+
+// Transform runs the transformers of the pipeline in order, each on the blocks returned by the one before
+func (p Pipeline) Transform(blocks []EditorJSBlock) ([]EditorJSBlock, error) {
+	for _, t := range p {
+		var err error
+		if blocks, err = t.Transform(blocks); err != nil {
+			return nil, err
+		}
+	}
+	return blocks, nil
+}
+
+// Transform returns a copy of the document with its blocks transformed by transformers. The document is left
+// unchanged.
+func (d *Document) Transform(transformers ...Transformer) (*Document, error) {
+	blocks, err := Pipeline(transformers).Transform(d.Blocks)
+	if err != nil {
+		return nil, err
+	}
+	doc := *d
+	doc.Blocks = blocks
+	return &doc, nil
+}
+
+// WithHTMLTransformers makes the HTMLEngine transform the blocks of the documents it generates, including the
+// documents nested in container blocks
+func WithHTMLTransformers(transformers ...Transformer) HTMLEngineOptions {
+	return func(h *HTMLEngine) {
+		h.Transformers = append(h.Transformers, transformers...)
+	}
+}
+
+// WithMarkdownTransformers makes the MarkdownEngine transform the blocks of the documents it generates, including the
+// documents nested in container blocks
+func WithMarkdownTransformers(transformers ...Transformer) MarkdownEngineOptions {
+	return func(m *MarkdownEngine) {
+		m.Transformers = append(m.Transformers, transformers...)
+	}
+}
+
+// blockOwners returns the index of the toggle owning each of blocks, -1 for the blocks at the top level, and the
+// number of blocks owned by each toggle
+func blockOwners(blocks []EditorJSBlock) ([]int, map[int]int) {
+	owners, counts := make([]int, len(blocks)), map[int]int{}
+	var assign func(from, to, owner int)
+	assign = func(from, to, owner int) {
+		for i := from; i < to; i++ {
+			owners[i] = owner
+			if blocks[i].Type != "toggle" {
+				continue
+			}
+			counts[i] = ownedBlockCount(&ToggleHandler{}, blocks[i], blocks[i+1:to])
+			assign(i+1, i+1+counts[i], i)
+			i += counts[i]
+		}
+	}
+	assign(0, len(blocks), -1)
+	return owners, counts
+}
+
+// removeBlocks returns the blocks that aren't removed. The items of the toggles owning removed blocks are reduced
+// accordingly, so they keep owning the same blocks.
+func removeBlocks(blocks []EditorJSBlock, removed []bool) ([]EditorJSBlock, error) {
+	owners, counts := blockOwners(blocks)
+	changed := map[int]bool{}
+	for i := range blocks {
+		if !removed[i] {
+			continue
+		}
+		for owner := owners[i]; owner >= 0; owner = owners[owner] {
+			counts[owner]--
+			changed[owner] = true
+		}
+	}
+
+	result := []EditorJSBlock{}
+	for i, block := range blocks {
+		if removed[i] {
+			continue
+		}
+		if changed[i] {
+			data := map[string]json.RawMessage{}
+			if err := json.Unmarshal(block.Data, &data); err != nil {
+				return nil, err
+			}
+			data["items"] = json.RawMessage(strconv.Itoa(counts[i]))
+			raw, err := json.Marshal(data)
+			if err != nil {
+				return nil, err
+			}
+			block.Data = raw
+		}
+		result = append(result, block)
+	}
+	return result, nil
+}
+
+// filterBlocks returns the blocks keep returns true for, keeping the blocks owned by toggles inside of them
+func filterBlocks(blocks []EditorJSBlock, keep func(block EditorJSBlock) bool) ([]EditorJSBlock, error) {
+	removed := make([]bool, len(blocks))
+	for i, block := range blocks {
+		removed[i] = !keep(block)
+	}
+	return removeBlocks(blocks, removed)
+}
+
+// DropEmptyParagraphs returns a Transformer removing the paragraphs without text, like the ones left by pressing
+// enter twice
+func DropEmptyParagraphs() Transformer {
+	return TransformerFunc(func(blocks []EditorJSBlock) ([]EditorJSBlock, error) {
+		return filterBlocks(blocks, func(block EditorJSBlock) bool {
+			return block.Type != "paragraph" || blockText(block) != ""
+		})
+	})
+}
+
+// StripRawBlocks returns a Transformer removing the raw html blocks
+func StripRawBlocks() Transformer {
+	return TransformerFunc(func(blocks []EditorJSBlock) ([]EditorJSBlock, error) {
+		return filterBlocks(blocks, func(block EditorJSBlock) bool {
+			return block.Type != "raw"
+		})
+	})
+}
+
+// DemoteHeaders returns a Transformer demoting the headers by levels, e.g. to render h1 as h2 below the title of a
+// page. Levels are capped at 6, negative levels promote the headers down to 1.
+func DemoteHeaders(levels int) Transformer {
+	return TransformerFunc(func(blocks []EditorJSBlock) ([]EditorJSBlock, error) {
+		result := make([]EditorJSBlock, len(blocks))
+		for i, block := range blocks {
+			result[i] = block
+			if block.Type != "header" {
+				continue
+			}
+			data := map[string]json.RawMessage{}
+			h := &Header{}
+			if err := json.Unmarshal(block.Data, &data); err != nil {
+				return nil, err
+			}
+			if err := json.Unmarshal(block.Data, h); err != nil {
+				return nil, err
+			}
+			level := h.Level + levels
+			if level < 1 {
+				level = 1
+			} else if level > 6 {
+				level = 6
+			}
+			data["level"] = json.RawMessage(strconv.Itoa(level))
+			raw, err := json.Marshal(data)
+			if err != nil {
+				return nil, err
+			}
+			result[i].Data = raw
+		}
+		return result, nil
+	})
+}
+
+// MergeLists returns a Transformer merging consecutive lists of the same style into one. Lists with tunes, like
+// footnotes, aren't merged into the list before them, neither are lists owned by another toggle than it.
+func MergeLists() Transformer {
+	return TransformerFunc(func(blocks []EditorJSBlock) ([]EditorJSBlock, error) {
+		owners, _ := blockOwners(blocks)
+		result := append([]EditorJSBlock{}, blocks...)
+		removed := make([]bool, len(blocks))
+		// list is the index of the list the following list is merged into, -1 if the previous block isn't a list
+		list := -1
+		var items []json.RawMessage
+		var previous map[string]json.RawMessage
+		for i, block := range blocks {
+			if block.Type != "list" {
+				list = -1
+				continue
+			}
+			data := map[string]json.RawMessage{}
+			if err := json.Unmarshal(block.Data, &data); err != nil {
+				return nil, err
+			}
+			blockItems := []json.RawMessage{}
+			if raw, ok := data["items"]; ok {
+				if err := json.Unmarshal(raw, &blockItems); err != nil {
+					return nil, err
+				}
+			}
+
+			if list < 0 || len(block.Tunes) > 0 || owners[list] != owners[i] ||
+				!equalJSON(previous["style"], data["style"]) {
+				list, items, previous = i, blockItems, data
+				continue
+			}
+			items = append(items, blockItems...)
+			raw, err := json.Marshal(items)
+			if err != nil {
+				return nil, err
+			}
+			previous["items"] = raw
+			merged, err := json.Marshal(previous)
+			if err != nil {
+				return nil, err
+			}
+			result[list].Data = merged
+			removed[i] = true
+		}
+		return removeBlocks(result, removed)
+	})
+}
+
+// CodeBoxToCode returns a Transformer converting the blocks of the CodeBox tool into the code blocks of the Code
+// tool. The contenteditable html of CodeBox is converted to plain text, its language is kept.
+func CodeBoxToCode() Transformer {
+	return TransformerFunc(func(blocks []EditorJSBlock) ([]EditorJSBlock, error) {
+		result := make([]EditorJSBlock, len(blocks))
+		for i, block := range blocks {
+			result[i] = block
+			if block.Type != "codeBox" {
+				continue
+			}
+			codeBox := &CodeBox{}
+			if err := json.Unmarshal(block.Data, codeBox); err != nil {
+				return nil, err
+			}
+			codeBox.Code = codeBoxText(codeBox.Code)
+			raw, err := json.Marshal(codeBox)
+			if err != nil {
+				return nil, err
+			}
+			result[i].Type, result[i].Data = "code", raw
+		}
+		return result, nil
+	})
+}
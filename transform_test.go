@@ -0,0 +1,116 @@
+package goeditorjs_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/davidscottmills/goeditorjs"
+	"github.com/stretchr/testify/require"
+)
+
+const transformData = `{"blocks": [
+	{"type": "header", "data": {"text": "Title", "level": 1}},
+	{"type": "paragraph", "data": {"text": "&nbsp;<br>", "alignment": "left"}},
+	{"type": "list", "data": {"style": "unordered", "items": ["a", "b"]}},
+	{"type": "list", "data": {"style": "unordered", "items": ["c"]}},
+	{"type": "list", "data": {"style": "ordered", "items": ["d"]}},
+	{"type": "raw", "data": {"html": "<script></script>"}},
+	{"type": "codeBox", "data": {"code": "<div>a &lt; b</div><div>c</div>", "language": "go"}},
+	{"type": "header", "data": {"text": "Sub", "level": 6}}
+]}`
+
+func Test_Document_Transform(t *testing.T) {
+	doc := parseDocument(t, transformData)
+	result, err := doc.Transform(goeditorjs.DropEmptyParagraphs(), goeditorjs.DemoteHeaders(1), goeditorjs.MergeLists(),
+		goeditorjs.CodeBoxToCode(), goeditorjs.StripRawBlocks())
+	require.NoError(t, err)
+
+	blocks := []string{}
+	for _, block := range result.Blocks {
+		blocks = append(blocks, block.Type+":"+string(block.Data))
+	}
+	require.Equal(t, []string{
+		`header:{"level":2,"text":"Title"}`,
+		`list:{"items":["a","b","c"],"style":"unordered"}`,
+		`list:{"style": "ordered", "items": ["d"]}`,
+		`code:{"code":"a \u003c b\nc","language":"go"}`,
+		`header:{"level":6,"text":"Sub"}`,
+	}, blocks)
+	// the document is left unchanged
+	require.Len(t, doc.Blocks, 8)
+	require.Equal(t, `{"text": "Title", "level": 1}`, string(doc.Blocks[0].Data))
+
+	result, err = doc.Transform(goeditorjs.DemoteHeaders(-3))
+	require.NoError(t, err)
+	require.Equal(t, `{"level":1,"text":"Title"}`, string(result.Blocks[0].Data))
+	require.Equal(t, `{"level":3,"text":"Sub"}`, string(result.Blocks[7].Data))
+}
+
+func Test_Pipeline_Error(t *testing.T) {
+	errTransform := errors.New("transform failed")
+	failing := goeditorjs.TransformerFunc(func(blocks []goeditorjs.EditorJSBlock) ([]goeditorjs.EditorJSBlock, error) {
+		return nil, errTransform
+	})
+
+	_, err := parseDocument(t, transformData).Transform(goeditorjs.StripRawBlocks(), failing)
+	require.True(t, errors.Is(err, errTransform))
+
+	eng := goeditorjs.NewHTMLEngine(goeditorjs.WithHTMLTransformers(failing))
+	_, err = eng.GenerateHTML(transformData)
+	require.True(t, errors.Is(err, errTransform))
+	_, err = eng.GenerateHTMLWithUnknownBlock(transformData)
+	require.True(t, errors.Is(err, errTransform))
+}
+
+func Test_Engines_Transformers(t *testing.T) {
+	data := `{"blocks": [
+		{"type": "header", "data": {"text": "Title", "level": 1}},
+		{"type": "paragraph", "data": {"text": "", "alignment": "left"}},
+		{"type": "columns", "data": {"cols": [{"blocks": [{"type": "header", "data": {"text": "Col", "level": 2}}]}]}}
+	]}`
+
+	htmlEngine := goeditorjs.NewHTMLEngine(goeditorjs.WithHTMLTransformers(goeditorjs.DropEmptyParagraphs(),
+		goeditorjs.DemoteHeaders(1)))
+	htmlEngine.RegisterBlockHandlers(&goeditorjs.HeaderHandler{}, &goeditorjs.ParagraphHandler{},
+		&goeditorjs.ColumnsHandler{})
+	result, err := htmlEngine.GenerateHTML(data)
+	require.NoError(t, err)
+	require.Equal(t, `<h2>Title</h2><div class="columns"><div class="columns__column"><h3>Col</h3></div></div>`, result)
+
+	markdownEngine := goeditorjs.NewMarkdownEngine(goeditorjs.WithMarkdownTransformers(goeditorjs.DropEmptyParagraphs(),
+		goeditorjs.DemoteHeaders(1)))
+	markdownEngine.RegisterBlockHandlers(&goeditorjs.HeaderHandler{}, &goeditorjs.ParagraphHandler{},
+		&goeditorjs.ColumnsHandler{})
+	result, err = markdownEngine.GenerateMarkdown(data)
+	require.NoError(t, err)
+	require.Equal(t, "## Title\n\n### Col", result)
+}
+
+func Test_Transformers_Keep_Toggle_Items(t *testing.T) {
+	data := `{"blocks": [
+		{"type": "toggle", "data": {"text": "T", "status": "open", "items": 6}},
+		{"type": "paragraph", "data": {"text": "", "alignment": "left"}},
+		{"type": "list", "data": {"style": "unordered", "items": ["a"]}},
+		{"type": "toggle", "data": {"text": "Inner", "status": "open", "items": 2}},
+		{"type": "list", "data": {"style": "unordered", "items": ["b"]}},
+		{"type": "raw", "data": {"html": "<hr>"}},
+		{"type": "list", "data": {"style": "unordered", "items": ["c"]}},
+		{"type": "list", "data": {"style": "unordered", "items": ["d"]}},
+		{"type": "paragraph", "data": {"text": "OUTSIDE", "alignment": "left"}}
+	]}`
+
+	htmlEngine := goeditorjs.NewHTMLEngine(goeditorjs.WithHTMLTransformers(goeditorjs.DropEmptyParagraphs(),
+		goeditorjs.StripRawBlocks(), goeditorjs.MergeLists()))
+	htmlEngine.RegisterBlockHandlers(&goeditorjs.ToggleHandler{}, &goeditorjs.ParagraphHandler{},
+		&goeditorjs.ListHandler{}, &goeditorjs.RawHTMLHandler{})
+	result, err := htmlEngine.GenerateHTML(data)
+	require.NoError(t, err)
+	require.Equal(t, `<details open><summary>T</summary><ul><li>a</li></ul>`+
+		`<details open><summary>Inner</summary><ul><li>b</li></ul></details>`+
+		`<ul><li>c</li></ul></details><ul><li>d</li></ul><p>OUTSIDE</p>`, result)
+
+	doc, err := parseDocument(t, data).Transform(goeditorjs.DropEmptyParagraphs(), goeditorjs.StripRawBlocks())
+	require.NoError(t, err)
+	require.Equal(t, `{"items":4,"status":"open","text":"T"}`, string(doc.Blocks[0].Data))
+	require.Equal(t, `{"items":1,"status":"open","text":"Inner"}`, string(doc.Blocks[2].Data))
+}
@@ -5,9 +5,12 @@ import (
 	"errors"
 )
 
-// editorJS rpresents the Editor JS data
-type editorJS struct {
-	Blocks []EditorJSBlock `json:"blocks"`
+// Document is a parsed EditorJS document
+type Document struct {
+	// Time is the time the document was saved in milliseconds since the epoch
+	Time    int64           `json:"time,omitempty"`
+	Blocks  []EditorJSBlock `json:"blocks"`
+	Version string          `json:"version,omitempty"`
 }
 
 // EditorJSBlock type
@@ -16,52 +19,167 @@ type EditorJSBlock struct {
 	Type string `json:"type"`
 	// Data is the Data for an editorJS block in the form of RawMessage ([]byte). It is left up to the Handler to parse the Data field
 	Data json.RawMessage `json:"data"`
+	// Tunes holds the data of the block tunes by tune name, e.g. the footnotes of the footnotes tune
+	Tunes map[string]json.RawMessage `json:"tunes,omitempty"`
 }
 
 var (
 	//ErrBlockHandlerNotFound is returned from GenerateHTML when the HTML engine doesn't have a registered handler
 	//for that type and the HTMLEngine is set to return on errors.
 	ErrBlockHandlerNotFound = errors.New("Handler not found for block type")
+	//ErrEngineRequired is returned by handlers of container blocks when they are called without an engine to
+	//render the nested blocks with.
+	ErrEngineRequired = errors.New("Handler requires an engine to render nested blocks")
 )
 
-// header represents header data from EditorJS
-type header struct {
+// Header represents header data from EditorJS
+type Header struct {
 	Text  string `json:"text"`
 	Level int    `json:"level"`
 }
 
-// paragraph represents paragraph data from EditorJS
-type paragraph struct {
+// Paragraph represents paragraph data from EditorJS
+type Paragraph struct {
 	Text      string `json:"text"`
 	Alignment string `json:"alignment"`
 }
 
-// list represents list data from EditorJS
-type list struct {
+// List represents list data from EditorJS
+type List struct {
 	Style string   `json:"style"`
 	Items []string `json:"items"`
 }
 
-// codeBox represents code box data from EditorJS
-type codeBox struct {
+// Quote represents quote data from EditorJS
+type Quote struct {
+	Text      string `json:"text"`
+	Caption   string `json:"caption"`
+	Alignment string `json:"alignment"`
+}
+
+// Warning represents warning data from EditorJS
+type Warning struct {
+	Title   string `json:"title"`
+	Message string `json:"message"`
+}
+
+// Alert represents alert data from the EditorJS alert tool
+type Alert struct {
+	Type    string `json:"type"`
+	Align   string `json:"align"`
+	Message string `json:"message"`
+}
+
+// CodeBox represents code box data from EditorJS
+type CodeBox struct {
 	Code     string `json:"code"`
 	Language string `json:"language"`
 }
 
-// raw represents raw html data from EditorJS
-type raw struct {
+// Raw represents raw html data from EditorJS
+type Raw struct {
 	HTML string `json:"html"`
 }
 
-// image represents image data from EditorJS
-type image struct {
-	File           file   `json:"file"`
+// Image represents image data from EditorJS
+type Image struct {
+	File File `json:"file"`
+	// URL is used by the SimpleImage tool instead of File.URL
+	URL            string `json:"url"`
 	Caption        string `json:"caption"`
 	WithBorder     bool   `json:"withBorder"`
 	WithBackground bool   `json:"withBackground"`
 	Stretched      bool   `json:"stretched"`
 }
 
-type file struct {
-	URL string `json:"url"`
+// File is the file of an image or media block
+type File struct {
+	URL    string  `json:"url"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+// Attaches represents file attachment data from EditorJS
+type Attaches struct {
+	File  AttachesFile `json:"file"`
+	Title string       `json:"title"`
+}
+
+// AttachesFile is the attached file of an Attaches block
+type AttachesFile struct {
+	URL       string  `json:"url"`
+	Name      string  `json:"name"`
+	Size      float64 `json:"size"`
+	Extension string  `json:"extension"`
+}
+
+// LinkTool represents link preview data from EditorJS
+type LinkTool struct {
+	Link string       `json:"link"`
+	Meta LinkToolMeta `json:"meta"`
+}
+
+// LinkToolMeta is the metadata the link tool fetched from the linked page
+type LinkToolMeta struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Image       File   `json:"image"`
+	SiteName    string `json:"site_name"`
+}
+
+// Math represents math data from EditorJS
+type Math struct {
+	Text string `json:"text"`
+}
+
+// Mermaid represents mermaid diagram data from EditorJS
+type Mermaid struct {
+	Code    string `json:"code"`
+	Caption string `json:"caption"`
+}
+
+// Columns represents the data of the editorjs-columns tool, whose columns are nested EditorJS documents
+type Columns struct {
+	Cols []Document `json:"cols"`
+}
+
+// Toggle represents the data of the editorjs-toggle-block tool. The toggle owns the blocks following it whose
+// parentId is its FK, or its id if it has no FK. Without such blocks, it owns the Items blocks following it.
+type Toggle struct {
+	Text   string `json:"text"`
+	Status string `json:"status"`
+	FK     string `json:"fk,omitempty"`
+	Items  int    `json:"items"`
+}
+
+// Media represents the audio and video data of custom EditorJS tools. The url is either in URL or in File.URL.
+type Media struct {
+	URL      string       `json:"url"`
+	File     File         `json:"file"`
+	Caption  string       `json:"caption"`
+	Poster   string       `json:"poster"`
+	Autoplay bool         `json:"autoplay"`
+	Controls *bool        `json:"controls"`
+	Loop     bool         `json:"loop"`
+	Muted    bool         `json:"muted"`
+	Tracks   []MediaTrack `json:"tracks"`
+}
+
+// MediaTrack is a text track, e.g. the captions of a video
+type MediaTrack struct {
+	Src     string `json:"src"`
+	Kind    string `json:"kind"`
+	SrcLang string `json:"srclang"`
+	Label   string `json:"label"`
+}
+
+// Checklist represents checklist data from EditorJS
+type Checklist struct {
+	Items []ChecklistItem `json:"items"`
+}
+
+// ChecklistItem is an item of a Checklist
+type ChecklistItem struct {
+	Text    string `json:"text"`
+	Checked bool   `json:"checked"`
 }
@@ -0,0 +1,92 @@
+package goeditorjs
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// URLRewriter rewrites the urls of images and links before they are rendered, e.g. to serve them from a CDN
+type URLRewriter func(u string) string
+
+// StaticDomainRewriter returns a URLRewriter that prefixes relative urls with domain, e.g. "https://cdn.example.com".
+// Absolute urls and data urls are left untouched.
+func StaticDomainRewriter(domain string) URLRewriter {
+	domain = strings.TrimRight(domain, "/")
+	return func(u string) string {
+		parsed, err := url.Parse(u)
+		if err != nil || u == "" || parsed.Scheme != "" || parsed.Host != "" {
+			return u
+		}
+		return domain + "/" + strings.TrimLeft(u, "/")
+	}
+}
+
+// rewrite applies the rewriter to u if it is set
+func (r URLRewriter) rewrite(u string) string {
+	if r == nil {
+		return u
+	}
+	return r(u)
+}
+
+// urlPath returns the path of u without query and fragment
+func urlPath(u string) string {
+	if i := strings.IndexAny(u, "?#"); i >= 0 {
+		return u[:i]
+	}
+	return u
+}
+
+// urlFileName returns the last segment of the path of u, or nothing if the path doesn't end in a file name, like the
+// path of "https://example.com" or of a directory
+func urlFileName(u string) string {
+	p := urlPath(u)
+	if parsed, err := url.Parse(u); err == nil {
+		p = parsed.Path
+	}
+	if p == "" || strings.HasSuffix(p, "/") {
+		return ""
+	}
+	if name := path.Base(p); name != "." && name != ".." {
+		return name
+	}
+	return ""
+}
+
+// safeURLSchemes are the schemes of the urls rendered as links
+var safeURLSchemes = map[string]bool{"http": true, "https": true, "mailto": true}
+
+// safeURL returns true if u is a relative url or an http, https or mailto url, which can't run scripts when followed
+func safeURL(u string) bool {
+	end := strings.IndexAny(u, ":/?#")
+	if end < 0 || u[end] != ':' {
+		return true
+	}
+	// browsers ignore whitespace and control characters in the scheme
+	scheme := strings.Map(func(r rune) rune {
+		if r <= ' ' {
+			return -1
+		}
+		return r
+	}, u[:end])
+	return safeURLSchemes[strings.ToLower(scheme)]
+}
+
+// hrefAttr returns the href attribute linking to u, or nothing if u isn't a safe url
+func hrefAttr(u string) string {
+	if !safeURL(u) {
+		return ""
+	}
+	return fmt.Sprintf(` href="%s"`, html.EscapeString(u))
+}
+
+// markdownLink returns the markdown link to u with the markdown text, or just the text if u isn't a safe url
+func markdownLink(text, u string) string {
+	if !safeURL(u) {
+		return text
+	}
+	return fmt.Sprintf("[%s](%s)", text, markdownURL(u))
+}
@@ -0,0 +1,18 @@
+package goeditorjs_test
+
+import (
+	"testing"
+
+	"github.com/davidscottmills/goeditorjs"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_StaticDomainRewriter(t *testing.T) {
+	rewrite := goeditorjs.StaticDomainRewriter("https://cdn.example.com/")
+	require.Equal(t, "https://cdn.example.com/img/a.png", rewrite("/img/a.png"))
+	require.Equal(t, "https://cdn.example.com/img/a.png", rewrite("img/a.png"))
+	require.Equal(t, "https://other.com/a.png", rewrite("https://other.com/a.png"))
+	require.Equal(t, "//other.com/a.png", rewrite("//other.com/a.png"))
+	require.Equal(t, "data:image/png;base64,AAAA", rewrite("data:image/png;base64,AAAA"))
+	require.Equal(t, "", rewrite(""))
+}
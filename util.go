@@ -1,13 +1,221 @@
 package goeditorjs
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+)
 
-// parseEditorJSON parses editorJS data
-func parseEditorJSON(editorJSData string) (*editorJS, error) {
-	result := &editorJS{}
+// ParseDocument parses editorJS data
+func ParseDocument(editorJSData string) (*Document, error) {
+	result := &Document{}
 	err := json.Unmarshal([]byte(editorJSData), result)
 	if err != nil {
 		return nil, err
 	}
 	return result, err
 }
+
+var (
+	inlineTagRegexp = regexp.MustCompile(`<[^>]*>`)
+	lineBreakRegexp = regexp.MustCompile(`<br\s*/?>`)
+)
+
+// plainText strips the inline markup of EditorJS text and decodes its html entities
+func plainText(text string) string {
+	text = inlineTagRegexp.ReplaceAllString(text, "")
+	text = html.UnescapeString(text)
+	return strings.ReplaceAll(text, "\u00a0", " ")
+}
+
+// codeBoxBlockTags start a new line in contenteditable html
+var codeBoxBlockTags = map[string]bool{"div": true, "p": true, "pre": true, "li": true}
+
+// codeBoxInlineTags are the tags a "<" has to start to be treated as markup rather than as text
+var codeBoxInlineTags = map[string]bool{
+	"br": true, "span": true, "font": true, "b": true, "strong": true, "i": true, "em": true, "u": true, "s": true,
+	"code": true, "mark": true, "a": true, "sub": true, "sup": true, "ol": true, "ul": true,
+}
+
+// codeBoxText returns the text of the contenteditable html stored by CodeBox. Block elements and <br> become line
+// breaks, all other tags, e.g. highlighting spans, are dropped and entities are decoded.
+// Unlike in html, a "<" that doesn't start a known tag is kept as text.
+func codeBoxText(code string) string {
+	sb := strings.Builder{}
+	lineStart := true
+	newline := func() {
+		sb.WriteByte('\n')
+		lineStart = true
+	}
+
+	for len(code) > 0 {
+		lt := strings.IndexByte(code, '<')
+		if lt < 0 {
+			lt = len(code)
+		}
+		if lt > 0 {
+			text := strings.ReplaceAll(html.UnescapeString(code[:lt]), "\u00a0", " ")
+			sb.WriteString(text)
+			lineStart = lineStart && text == ""
+			code = code[lt:]
+			continue
+		}
+
+		name, closing, length := codeBoxTag(code)
+		switch {
+		case length == 0:
+			sb.WriteByte('<')
+			lineStart = false
+			code = code[1:]
+			continue
+		case name == "br":
+			newline()
+		case codeBoxBlockTags[name] && !closing && !lineStart:
+			newline()
+		}
+		code = code[length:]
+	}
+
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// codeBoxTag returns the lowercased name of the known tag at the start of code, whether it is a closing tag and its
+// length. The length is 0 if code doesn't start with a known tag.
+func codeBoxTag(code string) (string, bool, int) {
+	end := strings.IndexByte(code, '>')
+	if end < 0 {
+		return "", false, 0
+	}
+
+	tag := code[1:end]
+	closing := strings.HasPrefix(tag, "/")
+	tag = strings.TrimPrefix(tag, "/")
+	name := tag
+	if i := strings.IndexAny(tag, " \t\n/"); i >= 0 {
+		name = tag[:i]
+	}
+	name = strings.ToLower(name)
+	if !codeBoxBlockTags[name] && !codeBoxInlineTags[name] {
+		return "", false, 0
+	}
+	return name, closing, end + 1
+}
+
+// documentTitle returns the plain text of the first header block of ejs
+func documentTitle(ejs *Document) string {
+	for _, block := range ejs.Blocks {
+		h := &Header{}
+		if block.Type == "header" && json.Unmarshal(block.Data, h) == nil {
+			return strings.TrimSpace(plainText(h.Text))
+		}
+	}
+	return ""
+}
+
+// documentDescription returns the plain text of the first paragraph block of ejs
+func documentDescription(ejs *Document) string {
+	for _, block := range ejs.Blocks {
+		p := &Paragraph{}
+		if block.Type == "paragraph" && json.Unmarshal(block.Data, p) == nil {
+			return strings.TrimSpace(plainText(p.Text))
+		}
+	}
+	return ""
+}
+
+// blockTexts returns the inline html of the text blocks: headers, paragraphs, lists, quotes with their captions,
+// warnings, alerts, checklists and tables. It is empty for all other blocks.
+func blockTexts(block EditorJSBlock) []string {
+	texts := []string{}
+	switch block.Type {
+	case "header":
+		h := &Header{}
+		if json.Unmarshal(block.Data, h) == nil {
+			texts = append(texts, h.Text)
+		}
+	case "paragraph":
+		p := &Paragraph{}
+		if json.Unmarshal(block.Data, p) == nil {
+			texts = append(texts, p.Text)
+		}
+	case "list":
+		l := &List{}
+		if json.Unmarshal(block.Data, l) == nil {
+			texts = append(texts, l.Items...)
+		}
+	case "quote":
+		q := &Quote{}
+		if json.Unmarshal(block.Data, q) == nil {
+			texts = append(texts, q.Text, q.Caption)
+		}
+	case "warning":
+		w := &Warning{}
+		if json.Unmarshal(block.Data, w) == nil {
+			texts = append(texts, w.Title, w.Message)
+		}
+	case "alert":
+		a := &Alert{}
+		if json.Unmarshal(block.Data, a) == nil {
+			texts = append(texts, a.Message)
+		}
+	case "checklist":
+		c := &Checklist{}
+		if json.Unmarshal(block.Data, c) == nil {
+			for _, item := range c.Items {
+				texts = append(texts, item.Text)
+			}
+		}
+	case "table":
+		t := &Table{}
+		if json.Unmarshal(block.Data, t) == nil {
+			for _, row := range t.Content {
+				texts = append(texts, row...)
+			}
+		}
+	}
+	return texts
+}
+
+// blockText returns the plain text of the text blocks, see blockTexts
+func blockText(block EditorJSBlock) string {
+	texts := blockTexts(block)
+	for i, text := range texts {
+		// line breaks separate words
+		texts[i] = strings.TrimSpace(plainText(lineBreakRegexp.ReplaceAllString(text, " ")))
+	}
+	return strings.TrimSpace(strings.Join(texts, " "))
+}
+
+// isCJK reports whether r is a Chinese, Japanese or Korean character. CJK text separates words without spaces.
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
+
+// countWords returns the number of words of text. Every CJK character counts as a word, other words are runs of
+// runes between spaces and CJK characters that hold a letter or a digit.
+func countWords(text string) int {
+	words := 0
+	inWord, wordHasContent := false, false
+	endWord := func() {
+		if inWord && wordHasContent {
+			words++
+		}
+		inWord, wordHasContent = false, false
+	}
+	for _, r := range text {
+		switch {
+		case isCJK(r):
+			endWord()
+			words++
+		case unicode.IsSpace(r):
+			endWord()
+		default:
+			inWord = true
+			wordHasContent = wordHasContent || unicode.IsLetter(r) || unicode.IsDigit(r)
+		}
+	}
+	endWord()
+	return words
+}
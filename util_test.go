@@ -6,9 +6,9 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func Test_parseEditorJSON(t *testing.T) {
+func Test_ParseDocument(t *testing.T) {
 	editorJSData := `{"time": 1607709186831,"blocks": [{"type": "header","data": {"text": "Heading 1","level": 1}}],"version": "2.19.1"}`
-	editorJS, err := parseEditorJSON(editorJSData)
+	editorJS, err := ParseDocument(editorJSData)
 	require.NoError(t, err)
 	require.Len(t, editorJS.Blocks, 1)
 }
@@ -16,6 +16,31 @@ func Test_parseEditorJSON(t *testing.T) {
 func Test_parseEditorJSON_Err_Empty(t *testing.T) {
 	editorJSData := ``
 
-	_, err := parseEditorJSON(editorJSData)
+	_, err := ParseDocument(editorJSData)
 	require.Error(t, err)
 }
+
+func Test_plainText(t *testing.T) {
+	require.Equal(t, "bold & link", plainText(`<b>bold</b> &amp; <a href="x">link</a>`))
+	require.Equal(t, "a b", plainText("a&nbsp;b"))
+}
+
+func Test_codeBoxText(t *testing.T) {
+	testData := []struct {
+		code     string
+		expected string
+	}{
+		{code: `a &lt; b &amp;&amp; c&nbsp;d`, expected: "a < b && c d"},
+		{code: `if a<b {}`, expected: "if a<b {}"},
+		{code: `vector<int> v;`, expected: "vector<int> v;"},
+		{code: `line1<div><br></div><div>line3</div>`, expected: "line1\n\nline3"},
+		{code: `<div>a<br></div><div>b</div>`, expected: "a\nb"},
+		{code: `a<br>b<BR/>c`, expected: "a\nb\nc"},
+		{code: `<span class="hljs-keyword">func</span> main`, expected: "func main"},
+		{code: `x >`, expected: "x >"},
+	}
+
+	for _, td := range testData {
+		require.Equal(t, td.expected, codeBoxText(td.code), td.code)
+	}
+}
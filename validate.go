@@ -0,0 +1,469 @@
+package goeditorjs
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ValidationError describes a schema problem found in an EditorJS document
+type ValidationError struct {
+	// BlockIndex is the index of the offending block, or -1 for document level problems
+	BlockIndex int    `json:"blockIndex"`
+	BlockID    string `json:"blockId,omitempty"`
+	BlockType  string `json:"blockType,omitempty"`
+	// Field is the path of the offending field inside the block data, e.g. "file.url"
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+func (v ValidationError) Error() string {
+	location := "document"
+	if v.BlockIndex >= 0 {
+		location = fmt.Sprintf("block %d (%s)", v.BlockIndex, v.BlockType)
+	}
+	if v.Field != "" {
+		return fmt.Sprintf("%s: %s: %s", location, v.Field, v.Message)
+	}
+	return fmt.Sprintf("%s: %s", location, v.Message)
+}
+
+// BlockValidator is an interface for a plugable EditorJS block schema validator
+type BlockValidator interface {
+	Type() string // Type returns the type the block validator supports as a string
+	// Validate returns the schema problems of the block. BlockIndex, BlockID and BlockType are filled in by the Validator.
+	Validate(editorJSBlock EditorJSBlock) []ValidationError
+}
+
+// Validator checks EditorJS documents against the schemas of its registered block validators
+type Validator struct {
+	BlockValidators map[string]BlockValidator
+	// AllowUnknownBlocks disables reporting blocks that have no registered validator
+	AllowUnknownBlocks bool
+}
+
+// NewValidator creates a new Validator with the validators of the built-in handlers registered
+func NewValidator() *Validator {
+	v := &Validator{BlockValidators: make(map[string]BlockValidator)}
+	v.RegisterBlockValidators(
+		&HeaderHandler{},
+		&ParagraphHandler{},
+		&ListHandler{},
+		&ChecklistHandler{},
+		&CodeBoxHandler{},
+		&CodeHandler{},
+		&RawHTMLHandler{},
+		&ImageHandler{},
+		&SimpleImageHandler{},
+		&TableHandler{},
+		&QuoteHandler{},
+		&DelimiterHandler{},
+		&WarningHandler{},
+		&AlertHandler{},
+		&LinkToolHandler{},
+		&AttachesHandler{},
+		&MathHandler{},
+		&MermaidHandler{},
+		&ColumnsHandler{},
+		&ToggleHandler{},
+		&MediaHandler{Kind: MediaAudio},
+		&MediaHandler{Kind: MediaVideo},
+	)
+	return v
+}
+
+// RegisterBlockValidators registers or overrides block validators for blockType given by BlockValidator.Type()
+func (validator *Validator) RegisterBlockValidators(validators ...BlockValidator) {
+	for _, bv := range validators {
+		validator.BlockValidators[bv.Type()] = bv
+	}
+}
+
+// Validate validates the editorJS data. An error is only returned if the data isn't valid JSON,
+// schema problems are reported in the returned slice.
+func (validator *Validator) Validate(editorJSData string) ([]ValidationError, error) {
+	doc := struct {
+		Blocks *[]json.RawMessage `json:"blocks"`
+	}{}
+	if err := json.Unmarshal([]byte(editorJSData), &doc); err != nil {
+		return nil, err
+	}
+	if doc.Blocks == nil {
+		return []ValidationError{{BlockIndex: -1, Field: "blocks", Message: "missing blocks array"}}, nil
+	}
+
+	problems := []ValidationError{}
+	ids := map[string]int{}
+	for i, rawBlock := range *doc.Blocks {
+		block := EditorJSBlock{}
+		if err := json.Unmarshal(rawBlock, &block); err != nil {
+			problems = append(problems, ValidationError{BlockIndex: i, Message: "block is not an object with a string type and id"})
+			continue
+		}
+
+		blockProblems := validator.validateBlock(block)
+		if block.ID != "" {
+			if first, ok := ids[block.ID]; ok {
+				blockProblems = append(blockProblems, ValidationError{Field: "id", Message: fmt.Sprintf("duplicate block id, first used by block %d", first)})
+			} else {
+				ids[block.ID] = i
+			}
+		}
+
+		for _, p := range blockProblems {
+			p.BlockIndex, p.BlockID, p.BlockType = i, block.ID, block.Type
+			problems = append(problems, p)
+		}
+	}
+
+	return problems, nil
+}
+
+func (validator *Validator) validateBlock(block EditorJSBlock) []ValidationError {
+	if block.Type == "" {
+		return []ValidationError{{Field: "type", Message: "missing block type"}}
+	}
+	if _, ok := decodeObject(block.Data); !ok {
+		return []ValidationError{{Field: "data", Message: "data must be an object"}}
+	}
+	bv, ok := validator.BlockValidators[block.Type]
+	if !ok {
+		if validator.AllowUnknownBlocks {
+			return nil
+		}
+		return []ValidationError{{Field: "type", Message: ErrBlockHandlerNotFound.Error()}}
+	}
+	return bv.Validate(block)
+}
+
+// schemaKind is the JSON kind expected for a field
+type schemaKind string
+
+const (
+	kindString schemaKind = "string"
+	kindNumber schemaKind = "number"
+	kindBool   schemaKind = "boolean"
+	kindObject schemaKind = "object"
+	kindArray  schemaKind = "array"
+)
+
+// schemaField describes a field of a block data object
+type schemaField struct {
+	name     string
+	kind     schemaKind
+	required bool
+}
+
+// validateSchema checks the fields of data against the schema. It returns the decoded object so callers can
+// perform further checks.
+func validateSchema(data json.RawMessage, fields ...schemaField) (map[string]interface{}, []ValidationError) {
+	obj, ok := decodeObject(data)
+	if !ok {
+		return nil, []ValidationError{{Field: "data", Message: "data must be an object"}}
+	}
+
+	problems := []ValidationError{}
+	for _, f := range fields {
+		value, present := obj[f.name]
+		if !present || value == nil {
+			if f.required {
+				problems = append(problems, ValidationError{Field: f.name, Message: "missing required field"})
+			}
+			continue
+		}
+		if kind := jsonKind(value); kind != f.kind {
+			problems = append(problems, ValidationError{Field: f.name, Message: fmt.Sprintf("expected %s, got %s", f.kind, kind)})
+		}
+	}
+	return obj, problems
+}
+
+func decodeObject(data json.RawMessage) (map[string]interface{}, bool) {
+	obj := map[string]interface{}{}
+	if err := json.Unmarshal(data, &obj); err != nil || obj == nil {
+		return nil, false
+	}
+	return obj, true
+}
+
+// hasField reports whether data is an object with a non null field name
+func hasField(data json.RawMessage, name string) bool {
+	obj, _ := decodeObject(data)
+	return obj[name] != nil
+}
+
+func jsonKind(value interface{}) schemaKind {
+	switch value.(type) {
+	case string:
+		return kindString
+	case float64:
+		return kindNumber
+	case bool:
+		return kindBool
+	case []interface{}:
+		return kindArray
+	case map[string]interface{}:
+		return kindObject
+	}
+	return "null"
+}
+
+// Validate validates the schema of header blocks
+func (*HeaderHandler) Validate(editorJSBlock EditorJSBlock) []ValidationError {
+	obj, problems := validateSchema(editorJSBlock.Data,
+		schemaField{name: "text", kind: kindString, required: true},
+		schemaField{name: "level", kind: kindNumber, required: true})
+	if level, ok := obj["level"].(float64); ok && (level < 1 || level > 6 || level != float64(int(level))) {
+		problems = append(problems, ValidationError{Field: "level", Message: "level must be an integer between 1 and 6"})
+	}
+	return problems
+}
+
+// Validate validates the schema of paragraph blocks
+func (*ParagraphHandler) Validate(editorJSBlock EditorJSBlock) []ValidationError {
+	obj, problems := validateSchema(editorJSBlock.Data,
+		schemaField{name: "text", kind: kindString, required: true},
+		schemaField{name: "alignment", kind: kindString})
+	if alignment, ok := obj["alignment"].(string); ok {
+		switch alignment {
+		case "left", "center", "right", "justify":
+		default:
+			problems = append(problems, ValidationError{Field: "alignment", Message: fmt.Sprintf("unknown alignment %q", alignment)})
+		}
+	}
+	return problems
+}
+
+// Validate validates the schema of list blocks
+func (*ListHandler) Validate(editorJSBlock EditorJSBlock) []ValidationError {
+	obj, problems := validateSchema(editorJSBlock.Data,
+		schemaField{name: "style", kind: kindString},
+		schemaField{name: "items", kind: kindArray, required: true})
+	if style, ok := obj["style"].(string); ok && style != "ordered" && style != "unordered" {
+		problems = append(problems, ValidationError{Field: "style", Message: fmt.Sprintf("unknown style %q", style)})
+	}
+	if items, ok := obj["items"].([]interface{}); ok {
+		for i, item := range items {
+			if kind := jsonKind(item); kind != kindString {
+				problems = append(problems, ValidationError{Field: fmt.Sprintf("items[%d]", i), Message: fmt.Sprintf("expected string, got %s", kind)})
+			}
+		}
+	}
+	return problems
+}
+
+// Validate validates the schema of code blocks
+func (*CodeBoxHandler) Validate(editorJSBlock EditorJSBlock) []ValidationError {
+	_, problems := validateSchema(editorJSBlock.Data,
+		schemaField{name: "code", kind: kindString, required: true},
+		schemaField{name: "language", kind: kindString})
+	return problems
+}
+
+// Validate validates the schema of raw blocks
+func (*RawHTMLHandler) Validate(editorJSBlock EditorJSBlock) []ValidationError {
+	_, problems := validateSchema(editorJSBlock.Data, schemaField{name: "html", kind: kindString, required: true})
+	return problems
+}
+
+// Validate validates the schema of image blocks
+func (*ImageHandler) Validate(editorJSBlock EditorJSBlock) []ValidationError {
+	obj, problems := validateSchema(editorJSBlock.Data,
+		schemaField{name: "file", kind: kindObject, required: !hasField(editorJSBlock.Data, "url")},
+		schemaField{name: "url", kind: kindString},
+		schemaField{name: "caption", kind: kindString},
+		schemaField{name: "withBorder", kind: kindBool},
+		schemaField{name: "withBackground", kind: kindBool},
+		schemaField{name: "stretched", kind: kindBool})
+	if file, ok := obj["file"].(map[string]interface{}); ok {
+		if url, ok := file["url"].(string); !ok || url == "" {
+			problems = append(problems, ValidationError{Field: "file.url", Message: "missing image url"})
+		}
+		for _, name := range []string{"width", "height"} {
+			if value, ok := file[name]; ok && jsonKind(value) != kindNumber {
+				problems = append(problems, ValidationError{Field: "file." + name, Message: fmt.Sprintf("expected number, got %s", jsonKind(value))})
+			}
+		}
+	}
+	return problems
+}
+
+// Validate validates the schema of table blocks
+func (*TableHandler) Validate(editorJSBlock EditorJSBlock) []ValidationError {
+	obj, problems := validateSchema(editorJSBlock.Data,
+		schemaField{name: "content", kind: kindArray, required: true},
+		schemaField{name: "withHeadings", kind: kindBool})
+	rows, _ := obj["content"].([]interface{})
+	for i, row := range rows {
+		cells, ok := row.([]interface{})
+		if !ok {
+			problems = append(problems, ValidationError{Field: fmt.Sprintf("content[%d]", i), Message: "expected array of cells"})
+			continue
+		}
+		for j, cell := range cells {
+			if _, ok := cell.(string); !ok {
+				problems = append(problems, ValidationError{Field: fmt.Sprintf("content[%d][%d]", i, j), Message: "expected string cell"})
+			}
+		}
+	}
+	return problems
+}
+
+// Validate validates the schema of quote blocks
+func (*QuoteHandler) Validate(editorJSBlock EditorJSBlock) []ValidationError {
+	_, problems := validateSchema(editorJSBlock.Data,
+		schemaField{name: "text", kind: kindString, required: true},
+		schemaField{name: "caption", kind: kindString},
+		schemaField{name: "alignment", kind: kindString})
+	return problems
+}
+
+// Validate validates the schema of delimiter blocks
+func (*DelimiterHandler) Validate(editorJSBlock EditorJSBlock) []ValidationError {
+	_, problems := validateSchema(editorJSBlock.Data)
+	return problems
+}
+
+// Validate validates the schema of warning blocks
+func (*WarningHandler) Validate(editorJSBlock EditorJSBlock) []ValidationError {
+	_, problems := validateSchema(editorJSBlock.Data,
+		schemaField{name: "title", kind: kindString},
+		schemaField{name: "message", kind: kindString, required: true})
+	return problems
+}
+
+// Validate validates the schema of alert blocks
+func (*AlertHandler) Validate(editorJSBlock EditorJSBlock) []ValidationError {
+	_, problems := validateSchema(editorJSBlock.Data,
+		schemaField{name: "type", kind: kindString},
+		schemaField{name: "align", kind: kindString},
+		schemaField{name: "message", kind: kindString, required: true})
+	return problems
+}
+
+// Validate validates the schema of link tool blocks
+func (*LinkToolHandler) Validate(editorJSBlock EditorJSBlock) []ValidationError {
+	obj, problems := validateSchema(editorJSBlock.Data,
+		schemaField{name: "link", kind: kindString, required: true},
+		schemaField{name: "meta", kind: kindObject})
+	if meta, ok := obj["meta"].(map[string]interface{}); ok {
+		for _, name := range []string{"title", "description", "site_name"} {
+			if value, ok := meta[name]; ok && jsonKind(value) != kindString {
+				problems = append(problems, ValidationError{Field: "meta." + name, Message: fmt.Sprintf("expected string, got %s", jsonKind(value))})
+			}
+		}
+	}
+	return problems
+}
+
+// Validate validates the schema of attaches blocks
+func (*AttachesHandler) Validate(editorJSBlock EditorJSBlock) []ValidationError {
+	obj, problems := validateSchema(editorJSBlock.Data,
+		schemaField{name: "file", kind: kindObject, required: true},
+		schemaField{name: "title", kind: kindString})
+	if file, ok := obj["file"].(map[string]interface{}); ok {
+		if url, ok := file["url"].(string); !ok || url == "" {
+			problems = append(problems, ValidationError{Field: "file.url", Message: "missing file url"})
+		}
+		if size, ok := file["size"]; ok && jsonKind(size) != kindNumber {
+			problems = append(problems, ValidationError{Field: "file.size", Message: fmt.Sprintf("expected number, got %s", jsonKind(size))})
+		}
+	}
+	return problems
+}
+
+// Validate validates the schema of math blocks
+func (*MathHandler) Validate(editorJSBlock EditorJSBlock) []ValidationError {
+	_, problems := validateSchema(editorJSBlock.Data, schemaField{name: "text", kind: kindString, required: true})
+	return problems
+}
+
+// Validate validates the schema of mermaid blocks
+func (*MermaidHandler) Validate(editorJSBlock EditorJSBlock) []ValidationError {
+	_, problems := validateSchema(editorJSBlock.Data,
+		schemaField{name: "code", kind: kindString, required: true},
+		schemaField{name: "caption", kind: kindString})
+	return problems
+}
+
+// Validate validates the schema of columns blocks. The blocks of the columns aren't validated.
+func (*ColumnsHandler) Validate(editorJSBlock EditorJSBlock) []ValidationError {
+	obj, problems := validateSchema(editorJSBlock.Data, schemaField{name: "cols", kind: kindArray, required: true})
+	cols, _ := obj["cols"].([]interface{})
+	for i, col := range cols {
+		doc, ok := col.(map[string]interface{})
+		if !ok {
+			problems = append(problems, ValidationError{Field: fmt.Sprintf("cols[%d]", i), Message: "expected document object"})
+			continue
+		}
+		if kind := jsonKind(doc["blocks"]); kind != kindArray {
+			problems = append(problems, ValidationError{Field: fmt.Sprintf("cols[%d].blocks", i), Message: fmt.Sprintf("expected array, got %s", kind)})
+		}
+	}
+	return problems
+}
+
+// Validate validates the schema of toggle blocks
+func (*ToggleHandler) Validate(editorJSBlock EditorJSBlock) []ValidationError {
+	obj, problems := validateSchema(editorJSBlock.Data,
+		schemaField{name: "text", kind: kindString, required: true},
+		schemaField{name: "status", kind: kindString},
+		schemaField{name: "items", kind: kindNumber})
+	if status, ok := obj["status"].(string); ok && status != "open" && status != "closed" {
+		problems = append(problems, ValidationError{Field: "status", Message: fmt.Sprintf("unknown status %q", status)})
+	}
+	if items, ok := obj["items"].(float64); ok && (items < 0 || items != float64(int(items))) {
+		problems = append(problems, ValidationError{Field: "items", Message: "items must be a non negative integer"})
+	}
+	return problems
+}
+
+// Validate validates the schema of checklist blocks
+func (*ChecklistHandler) Validate(editorJSBlock EditorJSBlock) []ValidationError {
+	obj, problems := validateSchema(editorJSBlock.Data, schemaField{name: "items", kind: kindArray, required: true})
+	items, _ := obj["items"].([]interface{})
+	for i, item := range items {
+		fields, ok := item.(map[string]interface{})
+		if !ok {
+			problems = append(problems, ValidationError{Field: fmt.Sprintf("items[%d]", i), Message: fmt.Sprintf("expected object, got %s", jsonKind(item))})
+			continue
+		}
+		if text, ok := fields["text"]; !ok || jsonKind(text) != kindString {
+			problems = append(problems, ValidationError{Field: fmt.Sprintf("items[%d].text", i), Message: "missing item text"})
+		}
+		if checked, ok := fields["checked"]; ok && jsonKind(checked) != kindBool {
+			problems = append(problems, ValidationError{Field: fmt.Sprintf("items[%d].checked", i), Message: fmt.Sprintf("expected boolean, got %s", jsonKind(checked))})
+		}
+	}
+	return problems
+}
+
+// Validate validates the schema of media blocks
+func (*MediaHandler) Validate(editorJSBlock EditorJSBlock) []ValidationError {
+	obj, problems := validateSchema(editorJSBlock.Data,
+		schemaField{name: "url", kind: kindString, required: !hasField(editorJSBlock.Data, "file")},
+		schemaField{name: "file", kind: kindObject},
+		schemaField{name: "caption", kind: kindString},
+		schemaField{name: "poster", kind: kindString},
+		schemaField{name: "autoplay", kind: kindBool},
+		schemaField{name: "controls", kind: kindBool},
+		schemaField{name: "loop", kind: kindBool},
+		schemaField{name: "muted", kind: kindBool},
+		schemaField{name: "tracks", kind: kindArray})
+	if file, ok := obj["file"].(map[string]interface{}); ok {
+		if url, ok := file["url"].(string); !ok || url == "" {
+			problems = append(problems, ValidationError{Field: "file.url", Message: "missing media url"})
+		}
+	}
+	tracks, _ := obj["tracks"].([]interface{})
+	for i, track := range tracks {
+		t, ok := track.(map[string]interface{})
+		if !ok {
+			problems = append(problems, ValidationError{Field: fmt.Sprintf("tracks[%d]", i), Message: "expected track object"})
+			continue
+		}
+		if src, ok := t["src"].(string); !ok || src == "" {
+			problems = append(problems, ValidationError{Field: fmt.Sprintf("tracks[%d].src", i), Message: "missing track url"})
+		}
+	}
+	return problems
+}
@@ -0,0 +1,78 @@
+package goeditorjs_test
+
+import (
+	"testing"
+
+	"github.com/davidscottmills/goeditorjs"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Validator_Validate_Returns_Parse_Err(t *testing.T) {
+	_, err := goeditorjs.NewValidator().Validate(``)
+	require.Error(t, err)
+}
+
+func Test_Validator_Validate_Valid(t *testing.T) {
+	editorJSData := `{"blocks": [
+		{"id": "a", "type": "header", "data": {"text": "Heading", "level": 1}},
+		{"id": "b", "type": "paragraph", "data": {"text": "paragraph", "alignment": "left"}},
+		{"type": "list", "data": {"style": "ordered", "items": ["one", "two"]}},
+		{"type": "image", "data": {"file": {"url": "https://example.com/a.jpg"}, "caption": ""}},
+		{"type": "table", "data": {"content": [["a", "b"], ["1", "2"]]}}
+	]}`
+	problems, err := goeditorjs.NewValidator().Validate(editorJSData)
+	require.NoError(t, err)
+	require.Empty(t, problems)
+}
+
+func Test_Validator_Validate_Problems(t *testing.T) {
+	editorJSData := `{"blocks": [
+		{"id": "a", "type": "header", "data": {"text": "Heading", "level": 7}},
+		{"id": "a", "type": "paragraph", "data": {"text": 1}},
+		{"type": "list", "data": {"style": "fancy", "items": ["one", 2]}},
+		{"type": "image", "data": {"file": {}}},
+		{"type": "unknown", "data": {}},
+		{"type": "raw", "data": "<p>"},
+		{"data": {}}
+	]}`
+	problems, err := goeditorjs.NewValidator().Validate(editorJSData)
+	require.NoError(t, err)
+
+	type location struct {
+		index int
+		field string
+	}
+	locations := []location{}
+	for _, p := range problems {
+		locations = append(locations, location{p.BlockIndex, p.Field})
+	}
+	require.Equal(t, []location{
+		{0, "level"},
+		{1, "text"},
+		{1, "id"},
+		{2, "style"},
+		{2, "items[1]"},
+		{3, "file.url"},
+		{4, "type"},
+		{5, "data"},
+		{6, "type"},
+	}, locations)
+	require.Equal(t, "header", problems[0].BlockType)
+	require.Equal(t, "a", problems[0].BlockID)
+	require.Equal(t, `block 0 (header): level: level must be an integer between 1 and 6`, problems[0].Error())
+}
+
+func Test_Validator_Validate_AllowUnknownBlocks(t *testing.T) {
+	v := goeditorjs.NewValidator()
+	v.AllowUnknownBlocks = true
+	problems, err := v.Validate(`{"blocks": [{"type": "unknown", "data": {}}]}`)
+	require.NoError(t, err)
+	require.Empty(t, problems)
+}
+
+func Test_Validator_Validate_Missing_Blocks(t *testing.T) {
+	problems, err := goeditorjs.NewValidator().Validate(`{"time": 1}`)
+	require.NoError(t, err)
+	require.Len(t, problems, 1)
+	require.Equal(t, -1, problems[0].BlockIndex)
+}
@@ -0,0 +1,306 @@
+package goeditorjs
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// blockDataTypes create the structs the data of the built-in block types is decoded into
+var blockDataTypes = map[string]func() interface{}{
+	"header":      func() interface{} { return &Header{} },
+	"paragraph":   func() interface{} { return &Paragraph{} },
+	"list":        func() interface{} { return &List{} },
+	"quote":       func() interface{} { return &Quote{} },
+	"warning":     func() interface{} { return &Warning{} },
+	"alert":       func() interface{} { return &Alert{} },
+	"code":        func() interface{} { return &CodeBox{} },
+	"codeBox":     func() interface{} { return &CodeBox{} },
+	"raw":         func() interface{} { return &Raw{} },
+	"image":       func() interface{} { return &Image{} },
+	"simpleImage": func() interface{} { return &Image{} },
+	"attaches":    func() interface{} { return &Attaches{} },
+	"linkTool":    func() interface{} { return &LinkTool{} },
+	"math":        func() interface{} { return &Math{} },
+	"mermaid":     func() interface{} { return &Mermaid{} },
+	"columns":     func() interface{} { return &Columns{} },
+	"toggle":      func() interface{} { return &Toggle{} },
+	"audio":       func() interface{} { return &Media{} },
+	"video":       func() interface{} { return &Media{} },
+	"checklist":   func() interface{} { return &Checklist{} },
+	"table":       func() interface{} { return &Table{} },
+}
+
+// DecodeBlock decodes the data of block into the struct of its type: *Header, *Paragraph, *List, *Quote, *Warning,
+// *Alert, *CodeBox for code and codeBox blocks, *Raw, *Image for image and simpleImage blocks, *Attaches, *LinkTool,
+// *Math, *Mermaid, *Columns, *Toggle, *Media for audio and video blocks, *Checklist or *Table. The data of other
+// block types is decoded into a map[string]interface{}.
+func DecodeBlock(block EditorJSBlock) (interface{}, error) {
+	newData, ok := blockDataTypes[block.Type]
+	if !ok {
+		data := map[string]interface{}{}
+		return data, json.Unmarshal(block.Data, &data)
+	}
+	data := newData()
+	return data, json.Unmarshal(block.Data, data)
+}
+
+// Node is a node visited by Walk: a *BlockNode, a *TextField or an *InlineNode
+type Node interface {
+	node()
+}
+
+// BlockNode is a block with its decoded data
+type BlockNode struct {
+	Block EditorJSBlock
+	// Data is the data of the block decoded by DecodeBlock
+	Data interface{}
+	// Parent is the container of the block, like the columns block of the blocks in its columns or the toggle owning
+	// the block. It is nil for the blocks at the top level of the document.
+	Parent *BlockNode
+	// Index is the index of the block in the blocks holding it: the document or the column of the parent
+	Index int
+}
+
+// TextField is a field of a block holding inline html, like the text of a paragraph or an item of a list
+type TextField struct {
+	Block *BlockNode
+	// Name is the name of the field in the data of the block, with the indexes of array elements, e.g. "text",
+	// "items[2]" or "content[1][0]"
+	Name string
+	// HTML is the inline html of the field
+	HTML string
+	// Nodes are the parsed inline html
+	Nodes []*InlineNode
+}
+
+// InlineNode is an element or a text node of inline html
+type InlineNode struct {
+	// Tag is the lower case name of an element, empty for text nodes
+	Tag string
+	// Attrs are the attributes of an element with their decoded values
+	Attrs map[string]string
+	// Text is the decoded text of a text node
+	Text string
+	// Children are the child nodes of an element
+	Children []*InlineNode
+	// Parent is the element holding the node, nil for the nodes at the top level of the field
+	Parent *InlineNode
+	// Field is the text field holding the node
+	Field *TextField
+}
+
+func (*BlockNode) node()  {}
+func (*TextField) node()  {}
+func (*InlineNode) node() {}
+
+// Visitor's Visit method is invoked for each node encountered by Walk. If the result visitor w is not nil, Walk
+// visits each of the children of node with the visitor w, followed by a call of w.Visit(nil).
+type Visitor interface {
+	Visit(node Node) (w Visitor)
+}
+
+// Walk traverses the blocks of doc in depth-first order: it starts by calling v.Visit(block) for each block. The
+// children of a block are its text fields, followed by the blocks nested in it: the blocks of the columns of a
+// columns block and the blocks owned by a toggle. The children of a text field are its inline nodes, the children of
+// an element are its child nodes. Walk returns the error of the first block whose data can't be decoded.
+func Walk(v Visitor, doc *Document) error {
+	return walkBlocks(v, doc.Blocks, 0, len(doc.Blocks), nil)
+}
+
+type inspector func(Node) bool
+
+func (f inspector) Visit(node Node) Visitor {
+	if f(node) {
+		return f
+	}
+	return nil
+}
+
+// Inspect traverses the blocks of doc in depth-first order like Walk: it starts by calling f(block) for each block.
+// If f returns true, Inspect invokes f recursively for each of the children of node, followed by a call of f(nil).
+func Inspect(doc *Document, f func(Node) bool) error {
+	return Walk(inspector(f), doc)
+}
+
+// walkBlocks walks the blocks from index from up to index to, which are held by parent
+func walkBlocks(v Visitor, blocks []EditorJSBlock, from, to int, parent *BlockNode) error {
+	for i := from; i < to; i++ {
+		owned := 0
+		if blocks[i].Type == "toggle" {
+			owned = ownedBlockCount(&ToggleHandler{}, blocks[i], blocks[i+1:to])
+		}
+		if err := walkBlock(v, blocks, i, owned, parent); err != nil {
+			return err
+		}
+		i += owned
+	}
+	return nil
+}
+
+// walkBlock walks the block at index i of blocks and the owned blocks following it
+func walkBlock(v Visitor, blocks []EditorJSBlock, i, owned int, parent *BlockNode) error {
+	data, err := DecodeBlock(blocks[i])
+	if err != nil {
+		return fmt.Errorf("block %d of type %s: %w", i, blocks[i].Type, err)
+	}
+	node := &BlockNode{Block: blocks[i], Data: data, Parent: parent, Index: i}
+	w := v.Visit(node)
+	if w == nil {
+		return nil
+	}
+
+	for _, field := range textFields(node) {
+		walkTextField(w, field)
+	}
+	if columns, ok := data.(*Columns); ok {
+		for _, col := range columns.Cols {
+			if err := walkBlocks(w, col.Blocks, 0, len(col.Blocks), node); err != nil {
+				return err
+			}
+		}
+	}
+	if err := walkBlocks(w, blocks, i+1, i+1+owned, node); err != nil {
+		return err
+	}
+	w.Visit(nil)
+	return nil
+}
+
+func walkTextField(v Visitor, field *TextField) {
+	w := v.Visit(field)
+	if w == nil {
+		return
+	}
+	for _, n := range field.Nodes {
+		walkInline(w, n)
+	}
+	w.Visit(nil)
+}
+
+func walkInline(v Visitor, node *InlineNode) {
+	w := v.Visit(node)
+	if w == nil {
+		return
+	}
+	for _, n := range node.Children {
+		walkInline(w, n)
+	}
+	w.Visit(nil)
+}
+
+// textFields returns the fields of the block holding inline html, with their html parsed
+func textFields(block *BlockNode) []*TextField {
+	fields := []*TextField{}
+	add := func(name, text string) {
+		if text == "" {
+			return
+		}
+		field := &TextField{Block: block, Name: name, HTML: text}
+		field.Nodes = parseInline(text, field)
+		fields = append(fields, field)
+	}
+
+	switch data := block.Data.(type) {
+	case *Header:
+		add("text", data.Text)
+	case *Paragraph:
+		add("text", data.Text)
+	case *List:
+		for i, item := range data.Items {
+			add(fmt.Sprintf("items[%d]", i), item)
+		}
+	case *Quote:
+		add("text", data.Text)
+		add("caption", data.Caption)
+	case *Warning:
+		add("title", data.Title)
+		add("message", data.Message)
+	case *Alert:
+		add("message", data.Message)
+	case *Image:
+		add("caption", data.Caption)
+	case *Toggle:
+		add("text", data.Text)
+	case *Media:
+		add("caption", data.Caption)
+	case *Checklist:
+		for i, item := range data.Items {
+			add(fmt.Sprintf("items[%d].text", i), item.Text)
+		}
+	case *Table:
+		for r, row := range data.Content {
+			for c, cell := range row {
+				add(fmt.Sprintf("content[%d][%d]", r, c), cell)
+			}
+		}
+	}
+	return fields
+}
+
+// attrRegexp matches an attribute of a tag and captures its name and its double quoted, single quoted or unquoted
+// value
+var attrRegexp = regexp.MustCompile(`([a-zA-Z_:][-a-zA-Z0-9_:.]*)(?:\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'=<>` + "`" + `]+)))?`)
+
+// voidElements are the elements without closing tag
+var voidElements = map[string]bool{"br": true, "img": true, "hr": true, "input": true, "wbr": true}
+
+// parseInline parses the inline html of field into nodes. Closing tags without open element are ignored, elements
+// that are never closed end with their parent.
+func parseInline(text string, field *TextField) []*InlineNode {
+	root := &InlineNode{}
+	open := []*InlineNode{root}
+	appendNode := func(node *InlineNode) {
+		parent := open[len(open)-1]
+		if parent != root {
+			node.Parent = parent
+		}
+		node.Field = field
+		parent.Children = append(parent.Children, node)
+	}
+
+	for len(text) > 0 {
+		match := inlineHTMLTagRegexp.FindStringSubmatch(text)
+		if match == nil {
+			end := strings.IndexByte(text[1:], '<') + 1
+			if end == 0 {
+				end = len(text)
+			}
+			content := html.UnescapeString(text[:end])
+			parent := open[len(open)-1]
+			if n := len(parent.Children); n > 0 && parent.Children[n-1].Tag == "" {
+				// text following a "<" that doesn't start a tag or an ignored closing tag
+				parent.Children[n-1].Text += content
+			} else {
+				appendNode(&InlineNode{Text: content})
+			}
+			text = text[end:]
+			continue
+		}
+
+		tag, closing, name := match[0], match[1] == "/", strings.ToLower(match[2])
+		text = text[len(tag):]
+		if closing {
+			for i := len(open) - 1; i > 0; i-- {
+				if open[i].Tag == name {
+					open = open[:i]
+					break
+				}
+			}
+			continue
+		}
+
+		node := &InlineNode{Tag: name, Attrs: map[string]string{}}
+		attrs := strings.TrimSuffix(strings.TrimPrefix(tag, "<"+match[2]), ">")
+		for _, m := range attrRegexp.FindAllStringSubmatch(strings.TrimSuffix(attrs, "/"), -1) {
+			node.Attrs[strings.ToLower(m[1])] = html.UnescapeString(m[2] + m[3] + m[4])
+		}
+		appendNode(node)
+		if !voidElements[name] && !strings.HasSuffix(tag, "/>") {
+			open = append(open, node)
+		}
+	}
+	return root.Children
+}
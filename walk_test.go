@@ -0,0 +1,138 @@
+package goeditorjs_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/davidscottmills/goeditorjs"
+	"github.com/stretchr/testify/require"
+)
+
+const walkData = `{"blocks": [
+	{"type": "header", "data": {"text": "Title", "level": 1}},
+	{"type": "paragraph", "data": {"text": "See <a href=\"https://a.com?x=1&amp;y=2\">the <b>docs</b></a> &amp; <br>more < less", "alignment": "left"}},
+	{"type": "toggle", "data": {"text": "Toggle", "items": 1}},
+	{"type": "list", "data": {"style": "unordered", "items": ["one", "<i>two"]}},
+	{"type": "columns", "data": {"cols": [
+		{"blocks": [{"type": "image", "data": {"file": {"url": "a.png"}, "caption": "<a href='https://b.com'>B</a>"}}]},
+		{"blocks": [{"type": "custom", "data": {"value": 1}}]}
+	]}},
+	{"type": "table", "data": {"withHeadings": false, "content": [["c</b>ell"]]}}
+]}`
+
+// describe returns a line describing node, indented by depth
+func describe(node goeditorjs.Node, depth int) string {
+	indent := strings.Repeat("  ", depth)
+	switch n := node.(type) {
+	case *goeditorjs.BlockNode:
+		parent := ""
+		if n.Parent != nil {
+			parent = " in " + n.Parent.Block.Type
+		}
+		return fmt.Sprintf("%s%s %d %T%s", indent, n.Block.Type, n.Index, n.Data, parent)
+	case *goeditorjs.TextField:
+		return fmt.Sprintf("%s%s %q", indent, n.Name, n.HTML)
+	case *goeditorjs.InlineNode:
+		if n.Tag == "" {
+			return fmt.Sprintf("%s%q", indent, n.Text)
+		}
+		return fmt.Sprintf("%s<%s> %v", indent, n.Tag, n.Attrs)
+	}
+	return ""
+}
+
+func Test_Inspect(t *testing.T) {
+	lines := []string{}
+	depth := 0
+	err := goeditorjs.Inspect(parseDocument(t, walkData), func(node goeditorjs.Node) bool {
+		if node == nil {
+			depth--
+			return false
+		}
+		lines = append(lines, describe(node, depth))
+		depth++
+		return true
+	})
+	require.NoError(t, err)
+	require.Equal(t, 0, depth)
+	require.Equal(t, []string{
+		`header 0 *goeditorjs.Header`,
+		`  text "Title"`,
+		`    "Title"`,
+		`paragraph 1 *goeditorjs.Paragraph`,
+		`  text "See <a href=\"https://a.com?x=1&amp;y=2\">the <b>docs</b></a> &amp; <br>more < less"`,
+		`    "See "`,
+		`    <a> map[href:https://a.com?x=1&y=2]`,
+		`      "the "`,
+		`      <b> map[]`,
+		`        "docs"`,
+		`    " & "`,
+		`    <br> map[]`,
+		`    "more < less"`,
+		`toggle 2 *goeditorjs.Toggle`,
+		`  text "Toggle"`,
+		`    "Toggle"`,
+		`  list 3 *goeditorjs.List in toggle`,
+		`    items[0] "one"`,
+		`      "one"`,
+		`    items[1] "<i>two"`,
+		`      <i> map[]`,
+		`        "two"`,
+		`columns 4 *goeditorjs.Columns`,
+		`  image 0 *goeditorjs.Image in columns`,
+		`    caption "<a href='https://b.com'>B</a>"`,
+		`      <a> map[href:https://b.com]`,
+		`        "B"`,
+		`  custom 0 map[string]interface {} in columns`,
+		`table 5 *goeditorjs.Table`,
+		`  content[0][0] "c</b>ell"`,
+		`    "cell"`,
+	}, lines)
+}
+
+// linkCollector is a Visitor collecting the hrefs of links and skipping tables
+type linkCollector struct {
+	links []string
+}
+
+func (c *linkCollector) Visit(node goeditorjs.Node) goeditorjs.Visitor {
+	switch n := node.(type) {
+	case *goeditorjs.BlockNode:
+		if _, ok := n.Data.(*goeditorjs.Table); ok {
+			return nil
+		}
+	case *goeditorjs.InlineNode:
+		if n.Tag == "a" {
+			c.links = append(c.links, n.Attrs["href"])
+		}
+	}
+	return c
+}
+
+func Test_Walk(t *testing.T) {
+	c := &linkCollector{}
+	require.NoError(t, goeditorjs.Walk(c, parseDocument(t, walkData)))
+	require.Equal(t, []string{"https://a.com?x=1&y=2", "https://b.com"}, c.links)
+
+	err := goeditorjs.Walk(c, parseDocument(t, `{"blocks": [{"type": "header", "data": {"level": "1"}}]}`))
+	require.Error(t, err)
+}
+
+func Test_DecodeBlock(t *testing.T) {
+	data, err := goeditorjs.DecodeBlock(goeditorjs.EditorJSBlock{Type: "codeBox", Data: []byte(`{"code": "x", "language": "go"}`)})
+	require.NoError(t, err)
+	require.Equal(t, &goeditorjs.CodeBox{Code: "x", Language: "go"}, data)
+
+	data, err = goeditorjs.DecodeBlock(goeditorjs.EditorJSBlock{Type: "video", Data: []byte(`{"url": "a.mp4"}`)})
+	require.NoError(t, err)
+	require.Equal(t, &goeditorjs.Media{URL: "a.mp4"}, data)
+
+	data, err = goeditorjs.DecodeBlock(goeditorjs.EditorJSBlock{Type: "image", Data: []byte(`{"file": {"url": "a.png", "width": 640.5}}`)})
+	require.NoError(t, err)
+	require.Equal(t, &goeditorjs.Image{File: goeditorjs.File{URL: "a.png", Width: 640.5}}, data)
+
+	data, err = goeditorjs.DecodeBlock(goeditorjs.EditorJSBlock{Type: "custom", Data: []byte(`{"a": "b"}`)})
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"a": "b"}, data)
+}